@@ -1,279 +1,337 @@
-package main
-
-import (
-    "bytes"
-    "encoding/json"
-    "fmt"
-    "os"
-    "os/exec"
-    "strings"
-    "time"
-)
-
-type SymbolicConfig struct {
-    Prefix            string  `json:"prefix"`
-    KG                string  `json:"KG"`
-    RulesFile         string  `json:"rules_file"`
-    RdfFile           string  `json:"rdf_file"`
-    ConstraintsFolder string  `json:"constraints_folder"`
-    PCAThreshold      float64 `json:"pca_threshold"`
-    SkipValidation    bool    `json:"skip_validation,omitempty"`
-}
-
-// DataFrame representation
-type DataFrame struct {
-    Columns []string               `json:"columns"`
-    Data    []map[string]interface{} `json:"data"`
-    Shape   []int                  `json:"shape"`
-    Dtypes  map[string]string      `json:"dtypes"`
-}
-
-// Triple representation
-type Triple struct {
-    Subject    string `json:"subject"`
-    Predicate  string `json:"predicate"`
-    Object     string `json:"object"`
-    ObjectType string `json:"object_type,omitempty"`
-}
-
-// Graph representation
-type GraphData struct {
-    Triples      []Triple          `json:"triples"`
-    TotalTriples int               `json:"total_triples"`
-    Namespaces   map[string]string `json:"namespaces"`
-    LimitedTo    *int              `json:"limited_to"`
-}
-
-// SPARQL Query info
-type QueryInfo struct {
-    Query         string  `json:"query"`
-    ExecutionTime float64 `json:"execution_time"`
-    ResultCount   int     `json:"result_count"`
-    Timestamp     string  `json:"timestamp"`
-}
-
-// Complete result structure
-type FullDataResult struct {
-    Success         bool      `json:"success"`
-    ExecutionTime   float64   `json:"execution_time"`
-    Timestamp       string    `json:"timestamp"`
-
-    // Predictions as DataFrame
-    PredictionsDataframe DataFrame `json:"predictions_dataframe"`
-
-    // New triples only
-    NewTriples []Triple `json:"new_triples"`
-
-    // Graph data
-    Graphs struct {
-        Initial GraphData `json:"initial"`
-        Enriched GraphData `json:"enriched"`
-        Statistics struct {
-            InitialTriples   int `json:"initial_triples"`
-            EnrichedTriples  int `json:"enriched_triples"`
-            PredictionsAdded int `json:"predictions_added"`
-        } `json:"statistics"`
-    } `json:"graphs"`
-
-    // Queries
-    SPARQLQueries []QueryInfo `json:"sparql_queries"`
-
-    // Summary
-    Summary struct {
-        TotalPredictions     int  `json:"total_predictions"`
-        QueriesExecuted      int  `json:"queries_executed"`
-        ProcessingSuccessful bool `json:"processing_successful"`
-    } `json:"summary"`
-
-    Error string `json:"error,omitempty"`
-}
-
-func callFullDataWrapper(config SymbolicConfig) (*FullDataResult, error) {
-    configJSON, err := json.Marshal(config)
-    if err != nil {
-        return nil, fmt.Errorf("failed to marshal config: %w", err)
-    }
-
-    cmd := exec.Command("python3", "full_data_wrapper.py")
-    cmd.Stdin = bytes.NewReader(configJSON)
-
-    var out bytes.Buffer
-    cmd.Stdout = &out
-
-    // Capture stderr for debugging
-    var stderr bytes.Buffer
-    cmd.Stderr = &stderr
-
-    fmt.Printf("Processing %s with full data capture...\n", config.KG)
-    err = cmd.Run()
-
-    // If there was an error, show stderr
-    if err != nil {
-        fmt.Printf("Error executing Python: %v\n", err)
-        if stderr.Len() > 0 {
-            fmt.Printf("Python stderr:\n%s\n", stderr.String())
-        }
-    }
-
-    var result FullDataResult
-    if err := json.Unmarshal(out.Bytes(), &result); err != nil {
-        return nil, fmt.Errorf("failed to parse output: %w\nRaw output: %s", err, out.String())
-    }
-
-    if err != nil && !result.Success {
-        return &result, fmt.Errorf("processing failed: %s", result.Error)
-    }
-
-    return &result, nil
-}
-
-func analyzeResults(result *FullDataResult) {
-    fmt.Println("\n📊 FULL DATA ANALYSIS")
-    fmt.Println(strings.Repeat("=", 50))
-
-    // DataFrame analysis
-    fmt.Printf("\n📋 Predictions DataFrame:\n")
-    if len(result.PredictionsDataframe.Shape) >= 2 {
-        fmt.Printf("   Shape: %dx%d\n", result.PredictionsDataframe.Shape[0], result.PredictionsDataframe.Shape[1])
-    }
-    fmt.Printf("   Columns: %v\n", result.PredictionsDataframe.Columns)
-
-    // Show sample predictions
-    if len(result.NewTriples) > 0 {
-        fmt.Printf("\n🔮 Sample Predictions (first 5):\n")
-        count := 5
-        if len(result.NewTriples) < 5 {
-            count = len(result.NewTriples)
-        }
-        for i := 0; i < count; i++ {
-            triple := result.NewTriples[i]
-            fmt.Printf("   %s -[%s]-> %s\n", triple.Subject, triple.Predicate, triple.Object)
-        }
-        if len(result.NewTriples) > 5 {
-            fmt.Printf("   ... and %d more predictions\n", len(result.NewTriples)-5)
-        }
-    } else {
-        fmt.Printf("\n⚠️  No predictions generated\n")
-    }
-
-    // Graph statistics
-    fmt.Printf("\n📈 Graph Statistics:\n")
-    fmt.Printf("   Initial triples: %d\n", result.Graphs.Statistics.InitialTriples)
-    fmt.Printf("   Enriched triples: %d\n", result.Graphs.Statistics.EnrichedTriples)
-    fmt.Printf("   New predictions: %d\n", result.Graphs.Statistics.PredictionsAdded)
-
-    // Query analysis
-    fmt.Printf("\n🔍 SPARQL Queries:\n")
-    fmt.Printf("   Total executed: %d\n", len(result.SPARQLQueries))
-
-    totalResults := 0
-    for _, query := range result.SPARQLQueries {
-        totalResults += query.ResultCount
-    }
-    fmt.Printf("   Total results: %d\n", totalResults)
-
-    // Show a sample query if available
-    if len(result.SPARQLQueries) > 0 {
-        fmt.Printf("\n   Sample query:\n")
-        query := result.SPARQLQueries[0]
-        lines := strings.Split(query.Query, "\n")
-        for _, line := range lines {
-            if len(strings.TrimSpace(line)) > 0 {
-                fmt.Printf("     %s\n", line)
-            }
-        }
-        fmt.Printf("   Results: %d, Time: %.3fs\n", query.ResultCount, query.ExecutionTime)
-    }
-
-    // Performance
-    fmt.Printf("\n⏱️  Performance:\n")
-    fmt.Printf("   Execution time: %.2f seconds\n", result.ExecutionTime)
-}
-
-func saveResultsToFile(result *FullDataResult, filename string) error {
-    data, err := json.MarshalIndent(result, "", "  ")
-    if err != nil {
-        return err
-    }
-
-    return os.WriteFile(filename, data, 0644)
-}
-
-func processMultipleKGs(configs []SymbolicConfig) {
-    var allPredictions []Triple
-    totalQueries := 0
-    successCount := 0
-
-    fmt.Printf("\n🔄 Processing %d Knowledge Graphs\n", len(configs))
-    fmt.Println(strings.Repeat("-", 50))
-
-    for i, config := range configs {
-        fmt.Printf("\n[%d/%d] Processing %s...\n", i+1, len(configs), config.KG)
-
-        result, err := callFullDataWrapper(config)
-        if err != nil {
-            fmt.Printf("❌ Error: %v\n", err)
-            continue
-        }
-
-        if result.Success {
-            successCount++
-            allPredictions = append(allPredictions, result.NewTriples...)
-            totalQueries += len(result.SPARQLQueries)
-
-            // Save individual results
-            filename := fmt.Sprintf("results_%s_%s.json", config.KG, time.Now().Format("20060102_150405"))
-            if err := saveResultsToFile(result, filename); err == nil {
-                fmt.Printf("✅ Saved results to %s\n", filename)
-            }
-
-            fmt.Printf("   Generated %d predictions\n", len(result.NewTriples))
-        }
-    }
-
-    fmt.Printf("\n📊 Aggregate Results:\n")
-    fmt.Println(strings.Repeat("-", 50))
-    fmt.Printf("   Successful KGs: %d/%d\n", successCount, len(configs))
-    fmt.Printf("   Total predictions: %d\n", len(allPredictions))
-    fmt.Printf("   Total queries: %d\n", totalQueries)
-}
-
-func main() {
-    fmt.Println("🚀 Full Data Symbolic Predictions Processor")
-    fmt.Println(strings.Repeat("=", 50))
-
-    // Check if wrapper exists
-    if _, err := os.Stat("full_data_wrapper.py"); os.IsNotExist(err) {
-        fmt.Println("❌ Error: full_data_wrapper.py not found")
-        fmt.Println("Please create the wrapper file first")
-        return
-    }
-
-    config := SymbolicConfig{
-        Prefix:            "http://FrenchRoyalty.org/", // Note the typo to match RDF
-        KG:                "FrenchRoyalty",
-        RulesFile:         "french_royalty.csv",
-        RdfFile:           "french_royalty.nt",
-        ConstraintsFolder: "FrenchRoyalty",
-        PCAThreshold:      0.7,
-        SkipValidation:    true,
-    }
-
-    result, err := callFullDataWrapper(config)
-    if err != nil {
-        fmt.Printf("❌ Error: %v\n", err)
-        return
-    }
-
-    if result.Success {
-        analyzeResults(result)
-
-        // Save complete results
-        filename := fmt.Sprintf("full_results_%s.json", time.Now().Format("20060102_150405"))
-        if err := saveResultsToFile(result, filename); err == nil {
-            fmt.Printf("\n💾 Complete results saved to: %s\n", filename)
-        }
-    } else {
-        fmt.Printf("❌ Processing failed: %s\n", result.Error)
-    }
-}
\ No newline at end of file
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/metrics"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/output"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/pipeline"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/server"
+)
+
+// outputExtension maps an --output-format value to the file extension
+// its serialized predictions are written with.
+func outputExtension(format string) string {
+	switch format {
+	case "ttl":
+		return "ttl"
+	case "jsonld":
+		return "jsonld"
+	case "parquet":
+		return "parquet"
+	default:
+		return "nt"
+	}
+}
+
+// writePredictions serializes rows into filename using the registered
+// Serializer named format.
+func writePredictions(format, filename string, rows []output.Row, namespaces map[string]string) error {
+	ser, err := output.ByName(format)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ser.Serialize(f, rows, namespaces)
+}
+
+func analyzeResults(result *pipeline.FullDataResult) {
+	fmt.Println("\n📊 FULL DATA ANALYSIS")
+	fmt.Println(strings.Repeat("=", 50))
+
+	// DataFrame analysis
+	fmt.Printf("\n📋 Predictions DataFrame:\n")
+	if len(result.PredictionsDataframe.Shape) >= 2 {
+		fmt.Printf("   Shape: %dx%d\n", result.PredictionsDataframe.Shape[0], result.PredictionsDataframe.Shape[1])
+	}
+	fmt.Printf("   Columns: %v\n", result.PredictionsDataframe.Columns)
+
+	// Show sample predictions
+	if len(result.NewTriples) > 0 {
+		fmt.Printf("\n🔮 Sample Predictions (first 5):\n")
+		count := 5
+		if len(result.NewTriples) < 5 {
+			count = len(result.NewTriples)
+		}
+		for i := 0; i < count; i++ {
+			triple := result.NewTriples[i]
+			fmt.Printf("   %s -[%s]-> %s\n", triple.Subject, triple.Predicate, triple.Object)
+		}
+		if len(result.NewTriples) > 5 {
+			fmt.Printf("   ... and %d more predictions\n", len(result.NewTriples)-5)
+		}
+	} else {
+		fmt.Printf("\n⚠️  No predictions generated\n")
+	}
+
+	// Graph statistics
+	fmt.Printf("\n📈 Graph Statistics:\n")
+	fmt.Printf("   Initial triples: %d\n", result.Graphs.Statistics.InitialTriples)
+	fmt.Printf("   Enriched triples: %d\n", result.Graphs.Statistics.EnrichedTriples)
+	fmt.Printf("   New predictions: %d\n", result.Graphs.Statistics.PredictionsAdded)
+
+	// Query analysis
+	fmt.Printf("\n🔍 SPARQL Queries:\n")
+	fmt.Printf("   Total executed: %d\n", len(result.SPARQLQueries))
+
+	totalResults := 0
+	for _, query := range result.SPARQLQueries {
+		totalResults += query.ResultCount
+	}
+	fmt.Printf("   Total results: %d\n", totalResults)
+
+	// Show a sample query if available
+	if len(result.SPARQLQueries) > 0 {
+		fmt.Printf("\n   Sample query:\n")
+		query := result.SPARQLQueries[0]
+		lines := strings.Split(query.Query, "\n")
+		for _, line := range lines {
+			if len(strings.TrimSpace(line)) > 0 {
+				fmt.Printf("     %s\n", line)
+			}
+		}
+		fmt.Printf("   Results: %d, Time: %.3fs\n", query.ResultCount, query.ExecutionTime)
+	}
+
+	// Performance
+	fmt.Printf("\n⏱️  Performance:\n")
+	fmt.Printf("   Execution time: %.2f seconds\n", result.ExecutionTime)
+}
+
+func saveResultsToFile(result *pipeline.FullDataResult, filename string) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
+
+// timeSerializing records how long fn took under the pipeline's
+// "serializing" stage, labeled with kg, the same way RunWithProgress
+// times parse/mine/predict/validate.
+func timeSerializing(kg string, fn func() error) error {
+	start := time.Now()
+	defer func() {
+		metrics.StageDuration.WithLabelValues(pipeline.StageSerializing, kg).Observe(time.Since(start).Seconds())
+	}()
+	return fn()
+}
+
+// processMultipleKGs runs configs through a bounded worker pool
+// (workers concurrent runs, a retry with exponential backoff for
+// transient failures, and optional fail-fast) instead of a serial
+// loop, writing each KG's individual result file as it lands, and
+// returns the consolidated AggregateResult for the caller to write.
+// When outputFormat is not "json", it also
+// writes a single consolidated predictions file in that format across
+// every KG (namespaces merged from each KG's enriched graph).
+func processMultipleKGs(configs []pipeline.SymbolicConfig, workers int, failFast bool, outputFormat string) *pipeline.AggregateResult {
+	fmt.Printf("\n🔄 Processing %d Knowledge Graphs with %d worker(s)\n", len(configs), workers)
+	fmt.Println(strings.Repeat("-", 50))
+
+	var nsMu sync.Mutex
+	namespaces := map[string]string{}
+
+	agg := pipeline.RunMultiple(configs, pipeline.MultiRunOptions{
+		Workers:    workers,
+		MaxRetries: 2,
+		FailFast:   failFast,
+		OnResult: func(config pipeline.SymbolicConfig, result *pipeline.FullDataResult, attempts int, err error) {
+			if err != nil || result == nil || !result.Success {
+				fmt.Printf("❌ %s failed after %d attempt(s): %v\n", config.KG, attempts, err)
+				return
+			}
+			filename := fmt.Sprintf("results_%s_%s.json", config.KG, time.Now().Format("20060102_150405"))
+			if saveErr := timeSerializing(config.KG, func() error { return saveResultsToFile(result, filename) }); saveErr == nil {
+				fmt.Printf("✅ %s: %d predictions, saved to %s (attempt %d)\n", config.KG, len(result.NewTriples), filename, attempts)
+			}
+
+			nsMu.Lock()
+			for prefix, iri := range result.Graphs.Enriched.Namespaces {
+				namespaces[prefix] = iri
+			}
+			nsMu.Unlock()
+		},
+	})
+
+	fmt.Printf("\n📊 Aggregate Results:\n")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("   Successful KGs: %d/%d\n", agg.SuccessfulKGs, agg.TotalKGs)
+	fmt.Printf("   Total deduplicated predictions: %d\n", len(agg.NewTriples))
+
+	if outputFormat != "" && outputFormat != "json" {
+		filename := fmt.Sprintf("aggregate_predictions_%s.%s", time.Now().Format("20060102_150405"), outputExtension(outputFormat))
+		err := timeSerializing("aggregate", func() error {
+			return writePredictions(outputFormat, filename, output.FromAggregate(agg.NewTriples), namespaces)
+		})
+		if err != nil {
+			fmt.Printf("❌ Error writing %s predictions: %v\n", outputFormat, err)
+		} else {
+			fmt.Printf("💾 Consolidated %s predictions saved to: %s\n", outputFormat, filename)
+		}
+	}
+
+	return agg
+}
+
+// workerCountFromEnv resolves the --workers default: DPKGC_WORKERS if
+// set and valid, otherwise the number of CPUs.
+func workerCountFromEnv() int {
+	if raw := os.Getenv("DPKGC_WORKERS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			return n
+		}
+	}
+	return runtime.NumCPU()
+}
+
+// runMulti implements the "multi" subcommand: process every KG config
+// in a JSON configs file concurrently and write one consolidated
+// aggregate-results file.
+func runMulti(args []string) {
+	fs := flag.NewFlagSet("multi", flag.ExitOnError)
+	workers := fs.Int("workers", workerCountFromEnv(), "number of KGs to process concurrently (env DPKGC_WORKERS)")
+	failFast := fs.Bool("fail-fast", false, "stop dispatching new KGs after the first failure")
+	outputFormat := fs.String("output-format", "json", "predictions output format: json, nt, ttl, jsonld, or parquet")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fmt.Println("usage: dpkgc multi [--workers N] [--fail-fast] [--output-format json|nt|ttl|jsonld|parquet] <configs.json>")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Printf("❌ Error reading %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+
+	var configs []pipeline.SymbolicConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		fmt.Printf("❌ Error parsing %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+
+	agg := processMultipleKGs(configs, *workers, *failFast, *outputFormat)
+
+	filename := fmt.Sprintf("aggregate_results_%s.json", time.Now().Format("20060102_150405"))
+	out, err := json.MarshalIndent(agg, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Error marshaling aggregate result: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filename, out, 0644); err != nil {
+		fmt.Printf("❌ Error writing %s: %v\n", filename, err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n💾 Consolidated aggregate results saved to: %s\n", filename)
+}
+
+// dataDirFromEnv resolves the server's --data-dir default:
+// DPKGC_DATA_DIR if set, otherwise the current directory. Every
+// /predict request's rdf_file/constraints_folder is sandboxed to this
+// directory so the server can't be made to read arbitrary host files.
+func dataDirFromEnv() string {
+	if dir := os.Getenv("DPKGC_DATA_DIR"); dir != "" {
+		return dir
+	}
+	return "."
+}
+
+func runServer(args []string) {
+	addr := ":8080"
+	dataDir := dataDirFromEnv()
+	var positional []string
+	for _, a := range args {
+		if rest, ok := strings.CutPrefix(a, "--data-dir="); ok {
+			dataDir = rest
+			continue
+		}
+		positional = append(positional, a)
+	}
+	if len(positional) > 0 {
+		addr = positional[0]
+	}
+
+	fmt.Printf("🌐 Starting DynamicPipeline-KGC server on %s (data dir: %s)\n", addr, dataDir)
+	if err := server.ListenAndServe(addr, dataDir); err != nil {
+		fmt.Printf("❌ Server error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runSingle(args []string) {
+	fs := flag.NewFlagSet("dpkgc", flag.ExitOnError)
+	outputFormat := fs.String("output-format", "json", "predictions output format: json, nt, ttl, jsonld, or parquet")
+	fs.Parse(args)
+
+	fmt.Println("🚀 Full Data Symbolic Predictions Processor")
+	fmt.Println(strings.Repeat("=", 50))
+
+	config := pipeline.SymbolicConfig{
+		Prefix:            "http://FrenchRoyalty.org/", // Note the typo to match RDF
+		KG:                "FrenchRoyalty",
+		RulesFile:         "french_royalty.csv",
+		RdfFile:           "french_royalty.nt",
+		ConstraintsFolder: "FrenchRoyalty",
+		PCAThreshold:      0.7,
+		SkipValidation:    true,
+	}
+
+	result, err := pipeline.Run(config)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return
+	}
+
+	if result.Success {
+		analyzeResults(result)
+
+		// Save complete results
+		filename := fmt.Sprintf("full_results_%s.json", time.Now().Format("20060102_150405"))
+		if err := timeSerializing(config.KG, func() error { return saveResultsToFile(result, filename) }); err == nil {
+			fmt.Printf("\n💾 Complete results saved to: %s\n", filename)
+		}
+
+		if *outputFormat != "" && *outputFormat != "json" {
+			predFilename := fmt.Sprintf("predictions_%s_%s.%s", config.KG, time.Now().Format("20060102_150405"), outputExtension(*outputFormat))
+			rows := output.FromPredicted(result.PredictionsDetailed, config.KG)
+			err := timeSerializing(config.KG, func() error {
+				return writePredictions(*outputFormat, predFilename, rows, result.Graphs.Enriched.Namespaces)
+			})
+			if err != nil {
+				fmt.Printf("❌ Error writing %s predictions: %v\n", *outputFormat, err)
+			} else {
+				fmt.Printf("💾 Predictions saved in %s format to: %s\n", *outputFormat, predFilename)
+			}
+		}
+	} else {
+		fmt.Printf("❌ Processing failed: %s\n", result.Error)
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "server" {
+		runServer(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "multi" {
+		runMulti(os.Args[2:])
+		return
+	}
+
+	runSingle(os.Args[1:])
+}
@@ -0,0 +1,50 @@
+// Package metrics holds the Prometheus collectors the pipeline reports
+// against, so operators running it as a batch/server process can see
+// which KGs and which rules dominate runtime.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	PredictionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dpkgc_predictions_total",
+		Help: "Total predicted triples produced per knowledge graph.",
+	}, []string{"kg"})
+
+	NewTriplesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dpkgc_new_triples_total",
+		Help: "Total new (not already present) triples added per knowledge graph.",
+	}, []string{"kg"})
+
+	StageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "dpkgc_stage_duration_seconds",
+		Help: "Duration of each pipeline stage (parsing, mining, predicting, validating, serializing).",
+	}, []string{"stage", "kg"})
+
+	RuleConfidence = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "dpkgc_rule_confidence",
+		Help:    "PCA confidence of mined rules that cleared the threshold.",
+		Buckets: prometheus.LinearBuckets(0, 0.1, 11),
+	})
+
+	SPARQLQueryDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "dpkgc_sparql_query_duration_seconds",
+		Help: "Duration of SPARQL-style graph queries served by the pipeline.",
+	})
+
+	ValidationViolationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dpkgc_validation_violations_total",
+		Help: "Total SHACL constraint violations found, by shape.",
+	}, []string{"shape"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		PredictionsTotal,
+		NewTriplesTotal,
+		StageDuration,
+		RuleConfidence,
+		SPARQLQueryDuration,
+		ValidationViolationsTotal,
+	)
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register(jsonldSerializer{})
+}
+
+type jsonldSerializer struct{}
+
+func (jsonldSerializer) Name() string { return "jsonld" }
+
+// jsonldNode is one "@id"-keyed node with a single predicate/object
+// pair, keyed by the predicate's compacted term so it resolves against
+// the document's own "@context"; rows sharing a subject are not merged
+// into one node, mirroring the flat, one-statement-per-entry style the
+// rest of this pipeline's JSON output already uses.
+type jsonldNode map[string]interface{}
+
+type jsonldDocument struct {
+	Context interface{}  `json:"@context"`
+	Graph   []jsonldNode `json:"@graph"`
+}
+
+// Serialize writes a single JSON-LD document whose "@context" is taken
+// from namespaces (prefix -> IRI, the same mapping Turtle uses for
+// compaction) and whose "@graph" holds one node per row, with the
+// row's subject/predicate/IRI-object compacted into prefixed terms so
+// the context actually defines the keys a consumer sees.
+func (jsonldSerializer) Serialize(w io.Writer, rows []Row, namespaces map[string]string) error {
+	context := map[string]string{}
+	for prefix, iri := range namespaces {
+		context[prefix] = iri
+	}
+
+	graph := make([]jsonldNode, 0, len(rows))
+	for _, r := range rows {
+		obj := interface{}(map[string]string{"@id": jsonldTerm(r.Object, namespaces)})
+		if r.ObjectType == "literal" {
+			obj = r.Object
+		}
+		graph = append(graph, jsonldNode{
+			"@id":                               jsonldTerm(r.Subject, namespaces),
+			jsonldTerm(r.Predicate, namespaces): obj,
+		})
+	}
+
+	doc := jsonldDocument{Context: context, Graph: graph}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// jsonldTerm compacts iri the way Turtle's compact does, but strips
+// the "<...>" wrapping compact falls back to for uncompactable IRIs —
+// that's Turtle syntax, not a valid JSON-LD term or "@id" value.
+func jsonldTerm(iri string, namespaces map[string]string) string {
+	term := compact(iri, namespaces)
+	if strings.HasPrefix(term, "<") && strings.HasSuffix(term, ">") {
+		return iri
+	}
+	return term
+}
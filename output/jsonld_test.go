@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONLDSerializeCompactsPredicateAndID(t *testing.T) {
+	ns := map[string]string{"ex": "http://example.org/"}
+	rows := []Row{
+		{Subject: "http://example.org/bob", Predicate: "http://example.org/hasNickname", Object: "\"Bobby\"", ObjectType: "literal"},
+		{Subject: "http://example.org/bob", Predicate: "http://example.org/parentOf", Object: "http://example.org/alice", ObjectType: "uri"},
+	}
+
+	ser, err := ByName("jsonld")
+	if err != nil {
+		t.Fatalf("ByName(jsonld): %v", err)
+	}
+	var buf strings.Builder
+	if err := ser.Serialize(&buf, rows, ns); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	var doc struct {
+		Context map[string]string        `json:"@context"`
+		Graph   []map[string]interface{} `json:"@graph"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &doc); err != nil {
+		t.Fatalf("unmarshal output: %v\n%s", err, buf.String())
+	}
+
+	if doc.Context["ex"] != "http://example.org/" {
+		t.Fatalf("@context missing ex prefix: %+v", doc.Context)
+	}
+	if len(doc.Graph) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(doc.Graph))
+	}
+
+	node := doc.Graph[0]
+	if node["@id"] != "ex:bob" {
+		t.Errorf("@id = %v, want ex:bob", node["@id"])
+	}
+	if node["ex:hasNickname"] != "\"Bobby\"" {
+		t.Errorf("ex:hasNickname = %v, want a plain literal value", node["ex:hasNickname"])
+	}
+
+	refNode := doc.Graph[1]
+	ref, ok := refNode["ex:parentOf"].(map[string]interface{})
+	if !ok || ref["@id"] != "ex:alice" {
+		t.Errorf("ex:parentOf = %v, want {\"@id\": \"ex:alice\"}", refNode["ex:parentOf"])
+	}
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(ntriplesSerializer{})
+}
+
+type ntriplesSerializer struct{}
+
+func (ntriplesSerializer) Name() string { return "nt" }
+
+// Serialize writes one "<s> <p> o ." line per row directly to a
+// buffered writer, so graphs with millions of rows don't need to be
+// materialized as one string first.
+func (ntriplesSerializer) Serialize(w io.Writer, rows []Row, namespaces map[string]string) error {
+	bw := bufio.NewWriter(w)
+	for _, r := range rows {
+		obj := r.Object
+		if r.ObjectType != "literal" {
+			obj = "<" + r.Object + ">"
+		}
+		if _, err := fmt.Fprintf(bw, "<%s> <%s> %s .\n", r.Subject, r.Predicate, obj); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNTriplesSerializeQuotesLiteralsNotIRIs(t *testing.T) {
+	rows := []Row{
+		{Subject: "ex:bob", Predicate: "ex:hasNickname", Object: "\"Bobby\"", ObjectType: "literal"},
+		{Subject: "ex:bob", Predicate: "ex:parentOf", Object: "ex:alice", ObjectType: "uri"},
+	}
+
+	var buf strings.Builder
+	ser, err := ByName("nt")
+	if err != nil {
+		t.Fatalf("ByName(nt): %v", err)
+	}
+	if err := ser.Serialize(&buf, rows, nil); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, `<ex:bob> <ex:hasNickname> "Bobby" .`) {
+		t.Errorf("literal object not written as a plain literal, got:\n%s", got)
+	}
+	if !strings.Contains(got, `<ex:bob> <ex:parentOf> <ex:alice> .`) {
+		t.Errorf("uri object not wrapped in angle brackets, got:\n%s", got)
+	}
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+func init() {
+	Register(parquetSerializer{})
+}
+
+type parquetSerializer struct{}
+
+func (parquetSerializer) Name() string { return "parquet" }
+
+// parquetRow is the on-disk schema: one row per predicted triple, with
+// its confidence, source rule and originating KG carried as columns so
+// a consolidated multi-KG export stays queryable without a join back
+// to the per-KG JSON results.
+type parquetRow struct {
+	Subject    string  `parquet:"name=subject, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Predicate  string  `parquet:"name=predicate, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Object     string  `parquet:"name=object, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ObjectType string  `parquet:"name=object_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Confidence float64 `parquet:"name=confidence, type=DOUBLE"`
+	SourceRule string  `parquet:"name=source_rule, type=BYTE_ARRAY, convertedtype=UTF8"`
+	KG         string  `parquet:"name=kg, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// Serialize writes rows as a single-row-group Parquet file. Parquet's
+// format requires row groups to be sized and footer-written up front,
+// so unlike N-Triples this needs every row in hand before it can write
+// anything.
+func (parquetSerializer) Serialize(w io.Writer, rows []Row, namespaces map[string]string) error {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(parquetRow), 4)
+	if err != nil {
+		return fmt.Errorf("create parquet writer: %w", err)
+	}
+
+	for _, r := range rows {
+		row := parquetRow{
+			Subject:    r.Subject,
+			Predicate:  r.Predicate,
+			Object:     r.Object,
+			ObjectType: r.ObjectType,
+			Confidence: r.Confidence,
+			SourceRule: r.SourceRule,
+			KG:         r.KG,
+		}
+		if err := pw.Write(row); err != nil {
+			return fmt.Errorf("write parquet row: %w", err)
+		}
+	}
+
+	return pw.WriteStop()
+}
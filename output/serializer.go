@@ -0,0 +1,104 @@
+// Package output implements pluggable serializers for predicted
+// triples: N-Triples, Turtle, JSON-LD, and Parquet, selected through
+// the CLI's --output-format flag instead of the single pretty-JSON
+// format saveResultsToFile used to hard-code.
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/pipeline"
+)
+
+// Row is the common shape every serializer writes from. It carries the
+// triple itself plus the provenance fields (Confidence, SourceRule, KG)
+// that only some formats (Parquet) surface as columns; formats that
+// don't use them (N-Triples, Turtle) simply ignore them.
+type Row struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	ObjectType string
+	Confidence float64
+	SourceRule string
+	KG         string
+}
+
+// FromTriples converts plain triples (no provenance) into rows.
+func FromTriples(triples []pipeline.Triple) []Row {
+	rows := make([]Row, len(triples))
+	for i, t := range triples {
+		rows[i] = Row{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, ObjectType: t.ObjectType}
+	}
+	return rows
+}
+
+// FromPredicted converts a single KG's confidence/rule-tagged
+// predictions into rows, stamping kg onto every row.
+func FromPredicted(triples []pipeline.PredictedTriple, kg string) []Row {
+	rows := make([]Row, len(triples))
+	for i, t := range triples {
+		rows[i] = Row{
+			Subject:    t.Subject,
+			Predicate:  t.Predicate,
+			Object:     t.Object,
+			ObjectType: t.ObjectType,
+			Confidence: t.Confidence,
+			SourceRule: t.SourceRule,
+			KG:         kg,
+		}
+	}
+	return rows
+}
+
+// FromAggregate converts a multi-KG AggregateResult's deduplicated
+// triples into rows, joining SourceKGs into a single comma-separated
+// KG column so a multi-KG run still produces one row per triple.
+func FromAggregate(triples []pipeline.AggregateTriple) []Row {
+	rows := make([]Row, len(triples))
+	for i, t := range triples {
+		rows[i] = Row{
+			Subject:    t.Subject,
+			Predicate:  t.Predicate,
+			Object:     t.Object,
+			ObjectType: t.ObjectType,
+			Confidence: t.Confidence,
+			SourceRule: t.SourceRule,
+			KG:         strings.Join(t.SourceKGs, ","),
+		}
+	}
+	return rows
+}
+
+// Serializer writes a set of rows to w in a specific RDF or columnar
+// format.
+type Serializer interface {
+	// Name is the --output-format value that selects this serializer.
+	Name() string
+
+	// Serialize writes rows to w. Implementations that support
+	// streaming (N-Triples, Turtle) write incrementally so multi-million
+	// triple graphs don't need to fit in memory as one buffer first;
+	// others (JSON-LD, Parquet) need the full slice to build one JSON
+	// document or write a single row group.
+	Serialize(w io.Writer, rows []Row, namespaces map[string]string) error
+}
+
+var registry = map[string]Serializer{}
+
+// Register adds s to the set of serializers ByName can return. Called
+// from each serializer's init().
+func Register(s Serializer) {
+	registry[s.Name()] = s
+}
+
+// ByName looks up a registered Serializer by its --output-format value.
+func ByName(name string) (Serializer, error) {
+	s, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q", name)
+	}
+	return s, nil
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register(turtleSerializer{})
+}
+
+type turtleSerializer struct{}
+
+func (turtleSerializer) Name() string { return "ttl" }
+
+// Serialize emits @prefix declarations for namespaces, then one
+// triple per line, compacting any term whose IRI starts with a known
+// namespace into a "prefix:local" name instead of a full <IRI>.
+func (turtleSerializer) Serialize(w io.Writer, rows []Row, namespaces map[string]string) error {
+	bw := bufio.NewWriter(w)
+
+	for prefix, iri := range namespaces {
+		if _, err := fmt.Fprintf(bw, "@prefix %s: <%s> .\n", prefix, iri); err != nil {
+			return err
+		}
+	}
+	if len(namespaces) > 0 {
+		if _, err := bw.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range rows {
+		obj := r.Object
+		if r.ObjectType != "literal" {
+			obj = compact(r.Object, namespaces)
+		}
+		if _, err := fmt.Fprintf(bw, "%s %s %s .\n", compact(r.Subject, namespaces), compact(r.Predicate, namespaces), obj); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// compact rewrites iri as "prefix:local" if it starts with one of the
+// known namespace IRIs, falling back to "<iri>" otherwise.
+func compact(iri string, namespaces map[string]string) string {
+	for prefix, ns := range namespaces {
+		if ns != "" && strings.HasPrefix(iri, ns) {
+			return prefix + ":" + strings.TrimPrefix(iri, ns)
+		}
+	}
+	return "<" + iri + ">"
+}
@@ -0,0 +1,14 @@
+package output
+
+import "testing"
+
+func TestCompactUsesPrefixWhenNamespaceMatches(t *testing.T) {
+	ns := map[string]string{"ex": "http://example.org/"}
+
+	if got := compact("http://example.org/bob", ns); got != "ex:bob" {
+		t.Errorf("compact() = %q, want %q", got, "ex:bob")
+	}
+	if got := compact("http://other.org/bob", ns); got != "<http://other.org/bob>" {
+		t.Errorf("compact() = %q, want %q", got, "<http://other.org/bob>")
+	}
+}
@@ -0,0 +1,211 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MultiRunOptions configures RunMultiple's worker pool.
+type MultiRunOptions struct {
+	// Workers bounds how many KGs run concurrently. Values < 1 are
+	// treated as 1.
+	Workers int
+
+	// MaxRetries is how many additional attempts a KG gets after a
+	// failed run, with exponential backoff between attempts. 0 means
+	// no retries.
+	MaxRetries int
+
+	// RetryBaseDelay is the backoff before the first retry; it doubles
+	// on each subsequent attempt. Defaults to 500ms if zero.
+	RetryBaseDelay time.Duration
+
+	// FailFast stops dispatching new KGs (in-flight ones still finish)
+	// as soon as one KG exhausts its retries.
+	FailFast bool
+
+	// OnResult, if set, is called from the worker goroutine as soon as
+	// each KG finishes (successfully or not), before its triples are
+	// merged into the AggregateResult. Callers use it to report
+	// progress or persist the per-KG FullDataResult as it lands.
+	OnResult func(config SymbolicConfig, result *FullDataResult, attempts int, err error)
+}
+
+// KGSummary is the per-KG outcome of a RunMultiple call.
+type KGSummary struct {
+	KG               string `json:"kg"`
+	Success          bool   `json:"success"`
+	Error            string `json:"error,omitempty"`
+	PredictionsAdded int    `json:"predictions_added"`
+	Attempts         int    `json:"attempts"`
+}
+
+// AggregateTriple is a predicted triple merged across KGs, carrying
+// which KG(s) it was independently predicted in.
+type AggregateTriple struct {
+	PredictedTriple
+	SourceKGs []string `json:"source_kgs"`
+}
+
+// AggregateResult is the merged, deduplicated output of running the
+// pipeline over several KGs.
+type AggregateResult struct {
+	NewTriples    []AggregateTriple `json:"new_triples"`
+	PerKG         []KGSummary       `json:"per_kg"`
+	TotalKGs      int               `json:"total_kgs"`
+	SuccessfulKGs int               `json:"successful_kgs"`
+}
+
+type runOutcome struct {
+	idx      int
+	config   SymbolicConfig
+	result   *FullDataResult
+	err      error
+	attempts int
+}
+
+// RunMultiple runs config for each KG in configs through a bounded
+// worker pool instead of a serial loop, retrying transient failures
+// with exponential backoff, and merges the results into a single
+// AggregateResult with per-KG provenance.
+func RunMultiple(configs []SymbolicConfig, opts MultiRunOptions) *AggregateResult {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.RetryBaseDelay <= 0 {
+		opts.RetryBaseDelay = 500 * time.Millisecond
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan int)
+	outcomes := make(chan runOutcome, len(configs))
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				config := configs[idx]
+				// ctx only gates dispatch, so a FailFast cancel leaves runs already in flight running.
+				result, attempts, err := runWithRetry(context.Background(), config, opts.MaxRetries, opts.RetryBaseDelay)
+				if opts.OnResult != nil {
+					opts.OnResult(config, result, attempts, err)
+				}
+				outcomes <- runOutcome{idx: idx, config: config, result: result, err: err, attempts: attempts}
+				if err != nil && opts.FailFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range configs {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	ordered := make([]*runOutcome, len(configs))
+	for o := range outcomes {
+		o := o
+		ordered[o.idx] = &o
+	}
+
+	return mergeOutcomes(configs, ordered)
+}
+
+// runWithRetry runs config, retrying up to maxRetries times with
+// exponential backoff, and recovers from panics in Run so one bad KG
+// can't take the whole worker pool down.
+func runWithRetry(ctx context.Context, config SymbolicConfig, maxRetries int, baseDelay time.Duration) (result *FullDataResult, attempts int, err error) {
+	for attempts = 1; attempts <= maxRetries+1; attempts++ {
+		result, err = runRecovered(ctx, config)
+		if err == nil {
+			return result, attempts, nil
+		}
+		if attempts > maxRetries {
+			break
+		}
+
+		backoff := baseDelay * time.Duration(1<<uint(attempts-1))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return result, attempts, ctx.Err()
+		}
+	}
+	return result, attempts, err
+}
+
+func runRecovered(ctx context.Context, config SymbolicConfig) (result *FullDataResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while processing %s: %v", config.KG, r)
+		}
+	}()
+	return RunWithProgress(ctx, config, nil)
+}
+
+func mergeOutcomes(configs []SymbolicConfig, ordered []*runOutcome) *AggregateResult {
+	agg := &AggregateResult{TotalKGs: len(configs)}
+	seen := map[[3]string]int{} // (s,p,o) -> index into agg.NewTriples
+
+	for i, o := range ordered {
+		config := configs[i]
+		summary := KGSummary{KG: config.KG}
+		if o == nil {
+			summary.Error = "not processed"
+			agg.PerKG = append(agg.PerKG, summary)
+			continue
+		}
+
+		summary.Attempts = o.attempts
+		if o.err != nil || o.result == nil || !o.result.Success {
+			summary.Error = errString(o.err, o.result)
+			agg.PerKG = append(agg.PerKG, summary)
+			continue
+		}
+
+		summary.Success = true
+		summary.PredictionsAdded = len(o.result.NewTriples)
+		agg.SuccessfulKGs++
+		agg.PerKG = append(agg.PerKG, summary)
+
+		for _, t := range o.result.PredictionsDetailed {
+			key := [3]string{t.Subject, t.Predicate, t.Object}
+			if idx, ok := seen[key]; ok {
+				agg.NewTriples[idx].SourceKGs = append(agg.NewTriples[idx].SourceKGs, config.KG)
+				continue
+			}
+			seen[key] = len(agg.NewTriples)
+			agg.NewTriples = append(agg.NewTriples, AggregateTriple{PredictedTriple: t, SourceKGs: []string{config.KG}})
+		}
+	}
+
+	return agg
+}
+
+func errString(err error, result *FullDataResult) string {
+	if err != nil {
+		return err.Error()
+	}
+	if result != nil && result.Error != "" {
+		return result.Error
+	}
+	return "processing failed"
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixtureGraph writes a tiny .nt graph that mines two length-1
+// rules (parentOf => hasChild and hasChild => parentOf), of which only
+// the first predicts a new triple (carol hasChild dan), so RunMultiple
+// has real, cheap work to do instead of needing a full French
+// Royalty-sized fixture.
+func writeFixtureGraph(t *testing.T, kg string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, kg+".nt")
+	content := `<http://example.org/alice> <http://example.org/parentOf> <http://example.org/bob> .
+<http://example.org/alice> <http://example.org/hasChild> <http://example.org/bob> .
+<http://example.org/carol> <http://example.org/parentOf> <http://example.org/dan> .
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func TestRunMultipleDedupesAcrossKGs(t *testing.T) {
+	configA := SymbolicConfig{KG: "A", RdfFile: writeFixtureGraph(t, "a"), PCAThreshold: 0.5}
+	configB := SymbolicConfig{KG: "B", RdfFile: writeFixtureGraph(t, "b"), PCAThreshold: 0.5}
+
+	agg := RunMultiple([]SymbolicConfig{configA, configB}, MultiRunOptions{Workers: 2})
+
+	if agg.TotalKGs != 2 || agg.SuccessfulKGs != 2 {
+		t.Fatalf("got total=%d successful=%d, want 2/2", agg.TotalKGs, agg.SuccessfulKGs)
+	}
+	for _, t2 := range agg.NewTriples {
+		if len(t2.SourceKGs) != 2 {
+			t.Errorf("triple %+v: got SourceKGs %v, want both KGs since both graphs are identical", t2, t2.SourceKGs)
+		}
+	}
+}
+
+func TestRunMultipleRecordsFailureWithoutStoppingOtherKGs(t *testing.T) {
+	good := SymbolicConfig{KG: "good", RdfFile: writeFixtureGraph(t, "good"), PCAThreshold: 0.5}
+	bad := SymbolicConfig{KG: "bad", RdfFile: "/no/such/file.nt", PCAThreshold: 0.5}
+
+	agg := RunMultiple([]SymbolicConfig{good, bad}, MultiRunOptions{Workers: 2})
+
+	if agg.TotalKGs != 2 || agg.SuccessfulKGs != 1 {
+		t.Fatalf("got total=%d successful=%d, want 2/1", agg.TotalKGs, agg.SuccessfulKGs)
+	}
+
+	var sawGood, sawBad bool
+	for _, s := range agg.PerKG {
+		switch s.KG {
+		case "good":
+			sawGood = s.Success
+		case "bad":
+			sawBad = !s.Success && s.Error != ""
+		}
+	}
+	if !sawGood {
+		t.Errorf("expected KG %q to succeed", "good")
+	}
+	if !sawBad {
+		t.Errorf("expected KG %q to fail with a recorded error", "bad")
+	}
+}
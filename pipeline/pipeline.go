@@ -0,0 +1,358 @@
+// Package pipeline holds the symbolic mining, prediction and
+// validation run that used to live directly in full_data_main.go. It
+// was pulled out so both the CLI entry point and the server subcommand
+// can run the same pipeline without the server importing package main.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/metrics"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/tracing"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/validation"
+)
+
+type SymbolicConfig struct {
+	Prefix            string  `json:"prefix"`
+	KG                string  `json:"KG"`
+	RulesFile         string  `json:"rules_file"`
+	RdfFile           string  `json:"rdf_file"`
+	ConstraintsFolder string  `json:"constraints_folder"`
+	PCAThreshold      float64 `json:"pca_threshold"`
+	SkipValidation    bool    `json:"skip_validation,omitempty"`
+}
+
+// DataFrame representation
+type DataFrame struct {
+	Columns []string                 `json:"columns"`
+	Data    []map[string]interface{} `json:"data"`
+	Shape   []int                    `json:"shape"`
+	Dtypes  map[string]string        `json:"dtypes"`
+}
+
+// Triple representation
+type Triple struct {
+	Subject    string `json:"subject"`
+	Predicate  string `json:"predicate"`
+	Object     string `json:"object"`
+	ObjectType string `json:"object_type,omitempty"`
+}
+
+// PredictedTriple is a predicted Triple tagged with the rule that
+// produced it, used wherever a caller needs that provenance (the
+// parquet output serializer, multi-KG aggregation) without it being
+// folded permanently into the plain Triple shape.
+type PredictedTriple struct {
+	Triple
+	Confidence float64 `json:"confidence"`
+	SourceRule string  `json:"source_rule"`
+}
+
+// Graph representation
+type GraphData struct {
+	Triples      []Triple          `json:"triples"`
+	TotalTriples int               `json:"total_triples"`
+	Namespaces   map[string]string `json:"namespaces"`
+	LimitedTo    *int              `json:"limited_to"`
+}
+
+// SPARQL Query info
+type QueryInfo struct {
+	Query         string  `json:"query"`
+	ExecutionTime float64 `json:"execution_time"`
+	ResultCount   int     `json:"result_count"`
+	Timestamp     string  `json:"timestamp"`
+}
+
+// Complete result structure
+type FullDataResult struct {
+	Success       bool    `json:"success"`
+	ExecutionTime float64 `json:"execution_time"`
+	Timestamp     string  `json:"timestamp"`
+
+	// Predictions as DataFrame
+	PredictionsDataframe DataFrame `json:"predictions_dataframe"`
+
+	// New triples only
+	NewTriples []Triple `json:"new_triples"`
+
+	// New triples with the confidence and rule that produced them
+	PredictionsDetailed []PredictedTriple `json:"predictions_detailed"`
+
+	// Graph data
+	Graphs struct {
+		Initial    GraphData `json:"initial"`
+		Enriched   GraphData `json:"enriched"`
+		Statistics struct {
+			InitialTriples   int `json:"initial_triples"`
+			EnrichedTriples  int `json:"enriched_triples"`
+			PredictionsAdded int `json:"predictions_added"`
+		} `json:"statistics"`
+	} `json:"graphs"`
+
+	// Queries
+	SPARQLQueries []QueryInfo `json:"sparql_queries"`
+
+	// SHACL validation of the initial and enriched graphs
+	ValidationReport struct {
+		Initial  validation.ValidationReport `json:"initial"`
+		Enriched validation.ValidationReport `json:"enriched"`
+	} `json:"validation_report"`
+
+	// Summary
+	Summary struct {
+		TotalPredictions     int  `json:"total_predictions"`
+		QueriesExecuted      int  `json:"queries_executed"`
+		ProcessingSuccessful bool `json:"processing_successful"`
+	} `json:"summary"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// Stage names reported through a ProgressFunc passed to RunWithProgress.
+const (
+	StageParsing    = "parsing"
+	StageMining     = "mining"
+	StagePredicting = "predicting"
+	StageValidating = "validating"
+	StageDone       = "done"
+
+	// StageSerializing isn't reported by RunWithProgress (writing
+	// results happens after it returns, in the CLI), but is shared
+	// here so callers label their metrics.StageDuration observations
+	// for that work the same way the stages above do.
+	StageSerializing = "serializing"
+)
+
+// ProgressFunc is called as Run moves through each pipeline stage.
+type ProgressFunc func(stage string)
+
+// Run executes the symbolic mining+prediction pipeline for config. It
+// used to shell out to full_data_wrapper.py; it now calls straight
+// into the symbolic package, but keeps producing the same
+// FullDataResult shape so existing callers don't need to change.
+func Run(config SymbolicConfig) (*FullDataResult, error) {
+	return RunWithProgress(context.Background(), config, nil)
+}
+
+// RunWithProgress is Run with a cancellable context and a stage
+// progress callback, for callers (the HTTP server's JobManager) that
+// need to stream progress or honor a deadline between stages. ctx is
+// only checked between stages, not inside them, since the mining and
+// validation loops below don't thread a context down to their
+// individual joins.
+func RunWithProgress(ctx context.Context, config SymbolicConfig, progress ProgressFunc) (*FullDataResult, error) {
+	report := func(stage string) {
+		if progress != nil {
+			progress(stage)
+		}
+	}
+	stageTimer := func(stage string) func() {
+		stageStart := time.Now()
+		return func() {
+			metrics.StageDuration.WithLabelValues(stage, config.KG).Observe(time.Since(stageStart).Seconds())
+		}
+	}
+
+	ctx, runSpan := tracing.Tracer().Start(ctx, "pipeline.Run")
+	defer runSpan.End()
+
+	start := time.Now()
+	fmt.Printf("Processing %s with full data capture...\n", config.KG)
+
+	if err := ctx.Err(); err != nil {
+		return errorResult(err), err
+	}
+	report(StageParsing)
+	_, parseSpan := tracing.Tracer().Start(ctx, StageParsing)
+	stopParseTimer := stageTimer(StageParsing)
+	initial, err := symbolic.ParseFile(config.RdfFile)
+	stopParseTimer()
+	parseSpan.End()
+	if err != nil {
+		wrapped := fmt.Errorf("failed to load %s: %w", config.RdfFile, err)
+		return errorResult(wrapped), wrapped
+	}
+
+	if err := ctx.Err(); err != nil {
+		return errorResult(err), err
+	}
+	report(StageMining)
+	_, mineSpan := tracing.Tracer().Start(ctx, StageMining)
+	stopMineTimer := stageTimer(StageMining)
+	engine := symbolic.NewAMIEEngine(initial, config.PCAThreshold)
+	rules, err := engine.Mine(nil)
+	stopMineTimer()
+	mineSpan.End()
+	if err != nil {
+		wrapped := fmt.Errorf("rule mining failed: %w", err)
+		return errorResult(wrapped), wrapped
+	}
+	for _, r := range rules {
+		metrics.RuleConfidence.Observe(r.Confidence)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return errorResult(err), err
+	}
+	report(StagePredicting)
+	_, predictSpan := tracing.Tracer().Start(ctx, StagePredicting)
+	stopPredictTimer := stageTimer(StagePredicting)
+	predicted, err := engine.PredictWithProvenance(initial)
+	stopPredictTimer()
+	predictSpan.End()
+	if err != nil {
+		wrapped := fmt.Errorf("prediction failed: %w", err)
+		return errorResult(wrapped), wrapped
+	}
+
+	enriched := symbolic.NewGraph()
+	for _, t := range initial.Triples {
+		enriched.Add(t)
+	}
+	for _, t := range predicted {
+		enriched.Add(t.Triple)
+	}
+
+	// Conforms defaults to true when validation never runs (no
+	// ConstraintsFolder configured), so "not validated" isn't
+	// indistinguishable from "failed validation" in the result.
+	initialReport := validation.ValidationReport{Conforms: true}
+	enrichedReport := validation.ValidationReport{Conforms: true}
+	if config.ConstraintsFolder != "" {
+		if err := ctx.Err(); err != nil {
+			return errorResult(err), err
+		}
+		report(StageValidating)
+		_, validateSpan := tracing.Tracer().Start(ctx, StageValidating)
+		stopValidateTimer := stageTimer(StageValidating)
+
+		shapes, err := validation.LoadShapes(config.ConstraintsFolder)
+		if err != nil {
+			stopValidateTimer()
+			validateSpan.End()
+			wrapped := fmt.Errorf("failed to load shapes from %s: %w", config.ConstraintsFolder, err)
+			return errorResult(wrapped), wrapped
+		}
+
+		initialReport = validation.Validate(initial, shapes)
+		enrichedReport = validation.Validate(enriched, shapes)
+		for _, v := range enrichedReport.Results {
+			metrics.ValidationViolationsTotal.WithLabelValues(v.SourceShape).Inc()
+		}
+
+		if !config.SkipValidation && !enrichedReport.Conforms {
+			predicted, enriched = dropViolatingPredictions(predicted, enrichedReport, initial)
+		}
+		stopValidateTimer()
+		validateSpan.End()
+	}
+
+	result := buildFullDataResult(initial, enriched, predicted, start)
+	result.ValidationReport.Initial = initialReport
+	result.ValidationReport.Enriched = enrichedReport
+	metrics.PredictionsTotal.WithLabelValues(config.KG).Add(float64(len(predicted)))
+	metrics.NewTriplesTotal.WithLabelValues(config.KG).Add(float64(len(predicted)))
+	report(StageDone)
+	return result, nil
+}
+
+func errorResult(err error) *FullDataResult {
+	return &FullDataResult{Success: false, Error: err.Error(), Timestamp: time.Now().Format(time.RFC3339)}
+}
+
+// dropViolatingPredictions removes predicted triples whose (subject,
+// predicate) pair is named by a shape violation, then rebuilds the
+// enriched graph from what survives. Violations with no result path
+// (whole-shape failures rather than property failures) can't be
+// attributed to a single triple and are left in place.
+func dropViolatingPredictions(predicted []symbolic.PredictedTriple, report validation.ValidationReport, initial *symbolic.Graph) ([]symbolic.PredictedTriple, *symbolic.Graph) {
+	rejected := map[[2]string]bool{}
+	for _, v := range report.Results {
+		if v.ResultPath != "" {
+			rejected[[2]string{v.FocusNode, v.ResultPath}] = true
+		}
+	}
+
+	var kept []symbolic.PredictedTriple
+	for _, t := range predicted {
+		if rejected[[2]string{t.Subject, t.Predicate}] {
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	enriched := symbolic.NewGraph()
+	for _, t := range initial.Triples {
+		enriched.Add(t)
+	}
+	for _, t := range kept {
+		enriched.Add(t.Triple)
+	}
+	return kept, enriched
+}
+
+// buildFullDataResult assembles a FullDataResult from the initial and
+// enriched graphs and the freshly predicted triples, mirroring the
+// JSON shape full_data_wrapper.py used to hand back.
+func buildFullDataResult(initial, enriched *symbolic.Graph, predicted []symbolic.PredictedTriple, start time.Time) *FullDataResult {
+	newTriples := make([]Triple, 0, len(predicted))
+	detailed := make([]PredictedTriple, 0, len(predicted))
+	rows := make([]map[string]interface{}, 0, len(predicted))
+	for _, t := range predicted {
+		triple := Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, ObjectType: t.ObjectType}
+		newTriples = append(newTriples, triple)
+		detailed = append(detailed, PredictedTriple{Triple: triple, Confidence: t.Confidence, SourceRule: t.SourceRule})
+		rows = append(rows, map[string]interface{}{
+			"subject":     t.Subject,
+			"predicate":   t.Predicate,
+			"object":      t.Object,
+			"object_type": t.ObjectType,
+		})
+	}
+
+	result := &FullDataResult{
+		Success:       true,
+		ExecutionTime: time.Since(start).Seconds(),
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PredictionsDataframe: DataFrame{
+			Columns: []string{"subject", "predicate", "object", "object_type"},
+			Data:    rows,
+			Shape:   []int{len(rows), 4},
+			Dtypes: map[string]string{
+				"subject": "object", "predicate": "object", "object": "object", "object_type": "object",
+			},
+		},
+		NewTriples:          newTriples,
+		PredictionsDetailed: detailed,
+	}
+
+	result.Graphs.Initial = ToGraphData(initial)
+	result.Graphs.Enriched = ToGraphData(enriched)
+	result.Graphs.Statistics.InitialTriples = len(initial.Triples)
+	result.Graphs.Statistics.EnrichedTriples = len(enriched.Triples)
+	result.Graphs.Statistics.PredictionsAdded = len(predicted)
+
+	result.Summary.TotalPredictions = len(predicted)
+	result.Summary.ProcessingSuccessful = true
+
+	return result
+}
+
+// ToGraphData converts a symbolic.Graph into the GraphData shape used
+// throughout FullDataResult; exported so the server package can reuse
+// it for the /kgs/{name}/graph endpoint.
+func ToGraphData(g *symbolic.Graph) GraphData {
+	triples := make([]Triple, 0, len(g.Triples))
+	for _, t := range g.Triples {
+		triples = append(triples, Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, ObjectType: t.ObjectType})
+	}
+	return GraphData{
+		Triples:      triples,
+		TotalTriples: len(triples),
+		Namespaces:   g.Namespaces,
+	}
+}
@@ -0,0 +1,281 @@
+// Package server exposes the pipeline over HTTP: POST /predict runs a
+// mining+prediction job, GET /kgs/{name}/graph serves back the
+// resulting graph with pagination, and GET /jobs/{id}/events streams
+// that job's stage-by-stage progress over Server-Sent Events.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/metrics"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/pipeline"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/tracing"
+)
+
+// ListenAndServe builds the routes and blocks serving them on addr.
+// Every /predict request's rdf_file and constraints_folder are
+// resolved relative to baseDir and rejected if they'd escape it, so a
+// request body can't read or enumerate arbitrary files on the host.
+// If OTEL_EXPORTER_JAEGER_ENDPOINT is set, every pipeline run's stages
+// are also exported as spans to that collector.
+func ListenAndServe(addr, baseDir string) error {
+	if endpoint := os.Getenv("OTEL_EXPORTER_JAEGER_ENDPOINT"); endpoint != "" {
+		shutdown, err := tracing.InitJaeger("dynamicpipeline-kgc", endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to init Jaeger exporter: %w", err)
+		}
+		defer shutdown(context.Background())
+	}
+	return http.ListenAndServe(addr, NewMux(NewJobManager(), baseDir))
+}
+
+// NewMux wires every route to jm so it can be exercised from tests or
+// embedded in another process without binding a socket.
+func NewMux(jm *JobManager, baseDir string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/predict", handlePredict(jm, baseDir))
+	mux.HandleFunc("/kgs/", handleKGGraph(jm))
+	mux.HandleFunc("/jobs/", handleJobEvents(jm))
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+// sandboxPath resolves name against baseDir and rejects it if it's
+// absolute or would resolve outside baseDir (e.g. via ".." segments),
+// so request bodies can only ever name files the operator already
+// placed under baseDir.
+func sandboxPath(baseDir, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("path %q must be relative to the server's data directory", name)
+	}
+	full := filepath.Join(baseDir, name)
+	rel, err := filepath.Rel(baseDir, full)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes the server's data directory", name)
+	}
+	return full, nil
+}
+
+// handlePredict decodes a SymbolicConfig body, runs it as a tracked
+// job, and returns the FullDataResult once the job finishes (or a 504
+// if X-Timeout elapses first; the job itself keeps running under its
+// own context and stays inspectable via /jobs/{id}/events — X-Timeout
+// only bounds how long this handler waits, not the job itself).
+func handlePredict(jm *JobManager, baseDir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "POST only", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var config pipeline.SymbolicConfig
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		var err error
+		if config.RdfFile, err = sandboxPath(baseDir, config.RdfFile); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if config.ConstraintsFolder, err = sandboxPath(baseDir, config.ConstraintsFolder); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if timeout, ok := parseTimeoutHeader(r.Header.Get("X-Timeout")); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		job := jm.Submit(config)
+		w.Header().Set("X-Job-Id", job.ID)
+
+		select {
+		case <-job.Done():
+			result, err := job.Result()
+			if err != nil && result == nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		case <-ctx.Done():
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusGatewayTimeout)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":  "timed out waiting for job",
+				"job_id": job.ID,
+			})
+		}
+	}
+}
+
+// handleKGGraph serves GET /kgs/{name}/graph, paginated via ?limit=
+// and ?offset= query params. It's the closest thing this pipeline has
+// to a SPARQL endpoint, so its latency is what
+// dpkgc_sparql_query_duration_seconds tracks.
+func handleKGGraph(jm *JobManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() { metrics.SPARQLQueryDuration.Observe(time.Since(start).Seconds()) }()
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "GET only", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name, ok := parseKGName(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		g, ok := jm.Graph(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("no graph known for KG %q yet", name), http.StatusNotFound)
+			return
+		}
+
+		data := pipeline.ToGraphData(g)
+		offset := parseIntParam(r.URL.Query(), "offset", 0)
+		limit := parseIntParam(r.URL.Query(), "limit", len(data.Triples))
+
+		if offset > len(data.Triples) {
+			offset = len(data.Triples)
+		}
+		end := offset + limit
+		if end > len(data.Triples) {
+			end = len(data.Triples)
+		}
+		page := data.Triples[offset:end]
+		data.Triples = page
+		data.LimitedTo = &limit
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data)
+	}
+}
+
+// handleJobEvents serves GET /jobs/{id}/events as Server-Sent Events:
+// it replays the job's history, then streams new stage transitions
+// until the job finishes or the client disconnects.
+func handleJobEvents(jm *JobManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseJobID(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		job, ok := jm.Job(id)
+		if !ok {
+			http.Error(w, fmt.Sprintf("no job %q", id), http.StatusNotFound)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		ch, history := job.Subscribe()
+		defer job.Unsubscribe(ch)
+
+		for _, event := range history {
+			writeSSE(w, event)
+		}
+		flusher.Flush()
+
+		for {
+			select {
+			case event, ok := <-ch:
+				if !ok {
+					return
+				}
+				writeSSE(w, event)
+				flusher.Flush()
+				if event.Stage == pipeline.StageDone {
+					return
+				}
+			case <-job.Done():
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", payload)
+}
+
+// parseKGName extracts {name} from "/kgs/{name}/graph".
+func parseKGName(path string) (string, bool) {
+	trimmed := strings.TrimPrefix(path, "/kgs/")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "graph" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// parseJobID extracts {id} from "/jobs/{id}/events".
+func parseJobID(path string) (string, bool) {
+	trimmed := strings.TrimPrefix(path, "/jobs/")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "events" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+func parseIntParam(values map[string][]string, key string, def int) int {
+	raw := values[key]
+	if len(raw) == 0 {
+		return def
+	}
+	n, err := strconv.Atoi(raw[0])
+	if err != nil || n < 0 {
+		return def
+	}
+	return n
+}
+
+// parseTimeoutHeader parses X-Timeout as a Go duration string (e.g.
+// "30s"); an empty or invalid header means "no deadline".
+func parseTimeoutHeader(raw string) (time.Duration, bool) {
+	if raw == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFixtureGraph(t *testing.T, dir, name string) {
+	t.Helper()
+	content := `<http://example.org/alice> <http://example.org/parentOf> <http://example.org/bob> .
+<http://example.org/alice> <http://example.org/hasChild> <http://example.org/bob> .
+`
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+}
+
+// TestHandlePredictJobOutlivesRequestTimeout is the regression test for
+// the bug where a job's context was derived from the request context:
+// net/http cancels r.Context() the instant the handler returns, so an
+// X-Timeout short enough to make the handler give up a 504 used to
+// abort the still-running job at its very next stage boundary too.
+func TestHandlePredictJobOutlivesRequestTimeout(t *testing.T) {
+	baseDir := t.TempDir()
+	writeFixtureGraph(t, baseDir, "graph.nt")
+
+	jm := NewJobManager()
+	mux := NewMux(jm, baseDir)
+
+	body := strings.NewReader(`{"KG":"test","rdf_file":"graph.nt","pca_threshold":0.5}`)
+	req := httptest.NewRequest("POST", "/predict", body)
+	req.Header.Set("X-Timeout", "1ns")
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != 504 {
+		t.Fatalf("got status %d, want 504 (X-Timeout should have elapsed first)", rec.Code)
+	}
+	jobID := rec.Header().Get("X-Job-Id")
+	if jobID == "" {
+		t.Fatalf("response missing X-Job-Id header")
+	}
+
+	job, ok := jm.Job(jobID)
+	if !ok {
+		t.Fatalf("job %q not found after the handler returned", jobID)
+	}
+
+	select {
+	case <-job.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("job %q never finished after the request's context was canceled", jobID)
+	}
+
+	result, err := job.Result()
+	if err != nil {
+		t.Fatalf("job finished with error %v, want it to complete the run despite the request timing out", err)
+	}
+	if result == nil || !result.Success {
+		t.Fatalf("job result = %+v, want a successful run", result)
+	}
+}
+
+func TestSandboxPathRejectsEscapes(t *testing.T) {
+	baseDir := t.TempDir()
+
+	if _, err := sandboxPath(baseDir, "/etc/hostname"); err == nil {
+		t.Error("expected an absolute path to be rejected")
+	}
+	if _, err := sandboxPath(baseDir, "../../etc/hostname"); err == nil {
+		t.Error("expected a path escaping baseDir via .. to be rejected")
+	}
+	if got, err := sandboxPath(baseDir, "graph.nt"); err != nil || got != filepath.Join(baseDir, "graph.nt") {
+		t.Errorf("sandboxPath(baseDir, %q) = %q, %v, want a path under baseDir and no error", "graph.nt", got, err)
+	}
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/pipeline"
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+// Event is one progress update emitted on a Job's SSE stream.
+type Event struct {
+	Stage     string `json:"stage"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Job tracks a single /predict run: its current stage, the events
+// emitted so far (replayed to subscribers that connect late), and its
+// eventual result.
+type Job struct {
+	ID     string
+	Cancel context.CancelFunc
+
+	mu     sync.Mutex
+	status string
+	events []Event
+	subs   map[chan Event]struct{}
+	done   chan struct{}
+	result *pipeline.FullDataResult
+	err    error
+}
+
+func newJob(id string, cancel context.CancelFunc) *Job {
+	return &Job{
+		ID:     id,
+		Cancel: cancel,
+		status: "queued",
+		subs:   map[chan Event]struct{}{},
+		done:   make(chan struct{}),
+	}
+}
+
+// Done returns a channel closed once the job's pipeline run returns.
+func (j *Job) Done() <-chan struct{} { return j.done }
+
+// Result returns the job's outcome; only meaningful after Done() is closed.
+func (j *Job) Result() (*pipeline.FullDataResult, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.result, j.err
+}
+
+// Subscribe registers a new SSE listener and returns its channel plus
+// the event history so far; the caller should replay the history then
+// read from the channel until it closes.
+func (j *Job) Subscribe() (ch chan Event, history []Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ch = make(chan Event, 16)
+	j.subs[ch] = struct{}{}
+	history = append(history, j.events...)
+	return ch, history
+}
+
+// Unsubscribe removes a listener registered via Subscribe.
+func (j *Job) Unsubscribe(ch chan Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.subs[ch]; ok {
+		delete(j.subs, ch)
+		close(ch)
+	}
+}
+
+func (j *Job) emit(stage string) {
+	event := Event{Stage: stage, Timestamp: time.Now().UTC().Format(time.RFC3339Nano)}
+	j.mu.Lock()
+	j.status = stage
+	j.events = append(j.events, event)
+	for ch := range j.subs {
+		select {
+		case ch <- event:
+		default: // slow subscriber, drop rather than block the job
+		}
+	}
+	j.mu.Unlock()
+}
+
+func (j *Job) finish(result *pipeline.FullDataResult, err error) {
+	j.mu.Lock()
+	j.result = result
+	j.err = err
+	j.mu.Unlock()
+	close(j.done)
+}
+
+// JobManager runs pipeline.RunWithProgress in the background for each
+// /predict request and keeps jobs around so /jobs/{id}/events can
+// stream their progress, and so the KG they produced can be served
+// back through /kgs/{name}/graph.
+type JobManager struct {
+	mu    sync.Mutex
+	jobs  map[string]*Job
+	graph map[string]*symbolic.Graph // last enriched graph per KG name
+	seq   uint64
+}
+
+// NewJobManager returns an empty JobManager.
+func NewJobManager() *JobManager {
+	return &JobManager{
+		jobs:  map[string]*Job{},
+		graph: map[string]*symbolic.Graph{},
+	}
+}
+
+// Submit starts config's pipeline run in a goroutine under a context
+// independent of any request, and returns the Job tracking it. Jobs
+// outlive the handler that submitted them (a /predict caller may stop
+// waiting on an X-Timeout without stopping the run), so Submit never
+// derives its context from the request: net/http cancels r.Context()
+// the instant the handler returns, which would otherwise abort the job
+// at the very next stage boundary. Job.Cancel still lets callers stop
+// a job explicitly.
+func (jm *JobManager) Submit(config pipeline.SymbolicConfig) *Job {
+	jctx, cancel := context.WithCancel(context.Background())
+
+	jm.mu.Lock()
+	jm.seq++
+	id := fmt.Sprintf("job-%d", jm.seq)
+	job := newJob(id, cancel)
+	jm.jobs[id] = job
+	jm.mu.Unlock()
+
+	go func() {
+		result, err := pipeline.RunWithProgress(jctx, config, job.emit)
+		if err == nil && result != nil {
+			jm.storeGraph(config, result)
+		}
+		job.finish(result, err)
+	}()
+
+	return job
+}
+
+// Job looks up a previously submitted job by ID.
+func (jm *JobManager) Job(id string) (*Job, bool) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	j, ok := jm.jobs[id]
+	return j, ok
+}
+
+// Graph returns the last enriched graph produced for a KG name.
+func (jm *JobManager) Graph(kg string) (*symbolic.Graph, bool) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	g, ok := jm.graph[kg]
+	return g, ok
+}
+
+func (jm *JobManager) storeGraph(config pipeline.SymbolicConfig, result *pipeline.FullDataResult) {
+	g := symbolic.NewGraph()
+	for _, t := range result.Graphs.Enriched.Triples {
+		g.Add(symbolic.Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, ObjectType: t.ObjectType})
+	}
+	jm.mu.Lock()
+	jm.graph[config.KG] = g
+	jm.mu.Unlock()
+}
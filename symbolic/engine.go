@@ -0,0 +1,253 @@
+package symbolic
+
+import "strings"
+
+// RuleEngine mines Horn rules from a graph and applies them to predict
+// new triples. It is the interface the rest of the pipeline codes
+// against, so alternative miners (e.g. a future type-constrained or
+// numeric-rule variant) can be swapped in without touching callers.
+type RuleEngine interface {
+	// Mine enumerates closed Horn rule candidates and returns those
+	// clearing the engine's PCA confidence threshold. A non-nil seed
+	// restricts mining to refinements of those rules instead of
+	// starting from scratch.
+	Mine(seed []Rule) ([]Rule, error)
+
+	// Predict grounds the engine's mined rules against kg and returns
+	// the triples they entail that are not already present in kg.
+	Predict(kg *Graph) ([]Triple, error)
+}
+
+// AMIEEngine is a bounded re-implementation of AMIE's core loop:
+// enumerate closed rule bodies up to MaxAtoms atoms, score each
+// candidate by PCA confidence against Graph, and keep the ones above
+// PCAThreshold.
+type AMIEEngine struct {
+	Graph        *Graph
+	PCAThreshold float64
+
+	// MaxAtoms bounds how many body atoms a rule may have. AMIE itself
+	// has no hard cap, but for the graph sizes this pipeline runs on a
+	// small bound keeps mining O(predicates^MaxAtoms) tractable.
+	MaxAtoms int
+
+	// MinSupport discards candidates with fewer than this many
+	// supporting (x,y) pairs, alongside the PCAThreshold filter.
+	MinSupport int
+
+	mined []Rule
+}
+
+// NewAMIEEngine returns an engine ready to mine g with the given PCA
+// confidence threshold, defaulting to 2-atom bodies and a minimum
+// support of 1.
+func NewAMIEEngine(g *Graph, pcaThreshold float64) *AMIEEngine {
+	return &AMIEEngine{
+		Graph:        g,
+		PCAThreshold: pcaThreshold,
+		MaxAtoms:     2,
+		MinSupport:   1,
+	}
+}
+
+// Mine enumerates length-1 and length-2 closed rule candidates (p1(x,y)
+// => p2(x,y), and p1(x,z)^p2(z,y) => p3(x,y)) over every predicate
+// triple in the graph, scores each by PCA confidence, and returns those
+// clearing e.PCAThreshold. Passing a non-empty seed mines only
+// refinements of those rules' heads rather than the full predicate
+// cross-product.
+func (e *AMIEEngine) Mine(seed []Rule) ([]Rule, error) {
+	predicates := e.Graph.Predicates()
+	var candidates []Rule
+
+	heads := predicates
+	if len(seed) > 0 {
+		heads = make([]string, 0, len(seed))
+		for _, r := range seed {
+			heads = append(heads, r.Head.Predicate)
+		}
+	}
+
+	for _, head := range heads {
+		for _, p1 := range predicates {
+			if p1 == head {
+				continue
+			}
+			if r, ok := e.scoreLength1(p1, head); ok {
+				candidates = append(candidates, r)
+			}
+		}
+
+		if e.MaxAtoms < 2 {
+			continue
+		}
+		for _, p1 := range predicates {
+			for _, p2 := range predicates {
+				if r, ok := e.scoreLength2(p1, p2, head); ok {
+					candidates = append(candidates, r)
+				}
+			}
+		}
+	}
+
+	var kept []Rule
+	for _, r := range candidates {
+		if r.Support >= e.MinSupport && r.Confidence >= e.PCAThreshold {
+			kept = append(kept, r)
+		}
+	}
+	e.mined = kept
+	return kept, nil
+}
+
+// scoreLength1 scores the rule p1(x,y) => head(x,y).
+func (e *AMIEEngine) scoreLength1(p1, head string) (Rule, bool) {
+	support := 0
+	pcaDenom := 0
+	for s, objs := range e.Graph.pso[p1] {
+		hasHeadFact := len(e.Graph.ObjectsForPredSubject(head, s)) > 0
+		for o := range objs {
+			if hasHeadFact {
+				pcaDenom++
+				if e.Graph.Has(s, head, o) {
+					support++
+				}
+			}
+		}
+	}
+	if pcaDenom == 0 {
+		return Rule{}, false
+	}
+	rule := Rule{
+		Body:       []Atom{{Predicate: p1, Subject: "x", Object: "y"}},
+		Head:       Atom{Predicate: head, Subject: "x", Object: "y"},
+		Support:    support,
+		PCABodyHit: pcaDenom,
+		Confidence: float64(support) / float64(pcaDenom),
+	}
+	return rule, true
+}
+
+// scoreLength2 scores the rule p1(x,z) ^ p2(z,y) => head(x,y).
+func (e *AMIEEngine) scoreLength2(p1, p2, head string) (Rule, bool) {
+	// Join p1(x,z) with p2(z,y) through the shared variable z.
+	joined := map[[2]string]bool{} // (x,y) pairs reachable via some z
+	for x, objs := range e.Graph.pso[p1] {
+		for z := range objs {
+			for y := range e.Graph.pso[p2][z] {
+				joined[[2]string{x, y}] = true
+			}
+		}
+	}
+	if len(joined) == 0 {
+		return Rule{}, false
+	}
+
+	support := 0
+	pcaDenom := 0
+	for xy := range joined {
+		x, y := xy[0], xy[1]
+		if len(e.Graph.ObjectsForPredSubject(head, x)) == 0 {
+			continue
+		}
+		pcaDenom++
+		if e.Graph.Has(x, head, y) {
+			support++
+		}
+	}
+	if pcaDenom == 0 {
+		return Rule{}, false
+	}
+	rule := Rule{
+		Body: []Atom{
+			{Predicate: p1, Subject: "x", Object: "z"},
+			{Predicate: p2, Subject: "z", Object: "y"},
+		},
+		Head:       Atom{Predicate: head, Subject: "x", Object: "y"},
+		Support:    support,
+		PCABodyHit: pcaDenom,
+		Confidence: float64(support) / float64(pcaDenom),
+	}
+	return rule, true
+}
+
+// PredictedTriple is a triple entailed by a mined rule, tagged with
+// the rule that produced it so callers (e.g. the parquet output
+// serializer) can keep that provenance.
+type PredictedTriple struct {
+	Triple
+	Confidence float64
+	SourceRule string
+}
+
+// Predict grounds every mined rule (from the most recent Mine call)
+// against kg and returns the triples they entail that kg does not
+// already contain.
+func (e *AMIEEngine) Predict(kg *Graph) ([]Triple, error) {
+	withProvenance, err := e.PredictWithProvenance(kg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Triple, len(withProvenance))
+	for i, t := range withProvenance {
+		out[i] = t.Triple
+	}
+	return out, nil
+}
+
+// PredictWithProvenance is Predict but keeps each triple's source rule
+// and confidence instead of discarding them.
+func (e *AMIEEngine) PredictWithProvenance(kg *Graph) ([]PredictedTriple, error) {
+	seen := map[[3]string]bool{}
+	var out []PredictedTriple
+
+	for _, r := range e.mined {
+		for _, t := range e.groundRule(r, kg) {
+			key := [3]string{t.Subject, t.Predicate, t.Object}
+			if seen[key] || kg.Has(t.Subject, t.Predicate, t.Object) {
+				continue
+			}
+			seen[key] = true
+			out = append(out, PredictedTriple{Triple: t, Confidence: r.Confidence, SourceRule: r.String()})
+		}
+	}
+	return out, nil
+}
+
+// groundRule substitutes rule.Body against kg and returns the head
+// atom instantiated for every satisfying binding.
+func (e *AMIEEngine) groundRule(r Rule, kg *Graph) []Triple {
+	switch len(r.Body) {
+	case 1:
+		body := r.Body[0]
+		var out []Triple
+		for s, objs := range kg.pso[body.Predicate] {
+			for o := range objs {
+				out = append(out, Triple{Subject: s, Predicate: r.Head.Predicate, Object: o, ObjectType: objectType(o)})
+			}
+		}
+		return out
+	case 2:
+		b1, b2 := r.Body[0], r.Body[1]
+		var out []Triple
+		for x, objs := range kg.pso[b1.Predicate] {
+			for z := range objs {
+				for y := range kg.pso[b2.Predicate][z] {
+					out = append(out, Triple{Subject: x, Predicate: r.Head.Predicate, Object: y, ObjectType: objectType(y)})
+				}
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// objectType classifies a bound object term the same way the parser
+// does: literals carry their surrounding quotes, IRIs don't.
+func objectType(o string) string {
+	if strings.HasPrefix(o, "\"") {
+		return "literal"
+	}
+	return "uri"
+}
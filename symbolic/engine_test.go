@@ -0,0 +1,68 @@
+package symbolic
+
+import "testing"
+
+// buildFamilyGraph mirrors the kind of small fixture the French Royalty
+// CSV/NT pair loads: one parent with a child, plus a calledAs literal
+// for each of them, so a length-1 rule (parentOf => hasChild, once a
+// hasChild fact is added) can be scored and a literal-object rule
+// (calledAs => hasNickname) can be ground.
+func buildFamilyGraph() *Graph {
+	g := NewGraph()
+	g.Add(Triple{Subject: "alice", Predicate: "parentOf", Object: "bob", ObjectType: "uri"})
+	g.Add(Triple{Subject: "alice", Predicate: "calledAs", Object: "\"Ally\"", ObjectType: "literal"})
+	g.Add(Triple{Subject: "bob", Predicate: "calledAs", Object: "\"Bobby\"", ObjectType: "literal"})
+	return g
+}
+
+func TestScoreLength1Confidence(t *testing.T) {
+	g := buildFamilyGraph()
+	g.Add(Triple{Subject: "alice", Predicate: "parentOf", Object: "bob"})
+	g.Add(Triple{Subject: "alice", Predicate: "hasChild", Object: "bob"})
+
+	e := NewAMIEEngine(g, 0.5)
+	rule, ok := e.scoreLength1("parentOf", "hasChild")
+	if !ok {
+		t.Fatalf("expected a rule to be scored")
+	}
+	if rule.Support != 1 || rule.Confidence != 1.0 {
+		t.Errorf("got support=%d confidence=%.2f, want support=1 confidence=1.00", rule.Support, rule.Confidence)
+	}
+}
+
+func TestGroundRulePreservesObjectType(t *testing.T) {
+	g := buildFamilyGraph()
+	e := NewAMIEEngine(g, 0.0)
+
+	rule := Rule{
+		Body:       []Atom{{Predicate: "calledAs", Subject: "x", Object: "y"}},
+		Head:       Atom{Predicate: "hasNickname", Subject: "x", Object: "y"},
+		Support:    1,
+		Confidence: 1.0,
+	}
+
+	triples := e.groundRule(rule, g)
+	if len(triples) == 0 {
+		t.Fatalf("expected groundRule to produce triples")
+	}
+	for _, tr := range triples {
+		if tr.ObjectType != "literal" {
+			t.Errorf("triple %+v: got ObjectType %q, want %q", tr, tr.ObjectType, "literal")
+		}
+	}
+}
+
+func TestObjectType(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"\"Bobby\"", "literal"},
+		{"http://example.org/bob", "uri"},
+	}
+	for _, c := range cases {
+		if got := objectType(c.in); got != c.want {
+			t.Errorf("objectType(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package symbolic
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseFile streams an .nt or .ttl file into a Graph, one line at a
+// time, so graphs that don't fit comfortably in a single string still
+// load in bounded memory. It covers the flat subset of Turtle this
+// pipeline's fixtures use (one "subject predicate object ." statement
+// per line, plus leading @prefix declarations); it does not implement
+// multi-line predicate/object lists or nested blank node syntax.
+func ParseFile(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	g := NewGraph()
+	prefixes := map[string]string{}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "@prefix") || strings.HasPrefix(line, "PREFIX") {
+			if prefix, iri, ok := parsePrefixLine(line); ok {
+				prefixes[prefix] = iri
+			}
+			continue
+		}
+		t, ok := parseTripleLine(line, prefixes)
+		if !ok {
+			continue // tolerate malformed/continuation lines, as the Python bridge did
+		}
+		g.Add(t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	g.Namespaces = prefixes
+	return g, nil
+}
+
+// parsePrefixLine parses "@prefix ex: <http://example.org/> ." into
+// ("ex", "http://example.org/").
+func parsePrefixLine(line string) (prefix, iri string, ok bool) {
+	fields := strings.Fields(line)
+	for i, f := range fields {
+		if strings.HasSuffix(f, ":") && i+1 < len(fields) {
+			iriField := strings.Trim(fields[i+1], "<>.")
+			return strings.TrimSuffix(f, ":"), iriField, true
+		}
+	}
+	return "", "", false
+}
+
+// parseTripleLine parses a single "s p o ." statement into a Triple,
+// expanding any prefixed names against prefixes and classifying the
+// object as a URI or a literal.
+func parseTripleLine(line string, prefixes map[string]string) (Triple, bool) {
+	line = strings.TrimSuffix(strings.TrimSpace(line), ".")
+	tokens := tokenizeTriple(line)
+	if len(tokens) < 3 {
+		return Triple{}, false
+	}
+
+	s := expandTerm(tokens[0], prefixes)
+	p := expandTerm(tokens[1], prefixes)
+	objRaw := strings.Join(tokens[2:], " ")
+	o := expandTerm(objRaw, prefixes)
+
+	objType := "uri"
+	if strings.HasPrefix(strings.TrimSpace(objRaw), "\"") {
+		objType = "literal"
+	}
+
+	return Triple{Subject: s, Predicate: p, Object: o, ObjectType: objType}, true
+}
+
+// tokenizeTriple splits "s p o" on whitespace while keeping quoted
+// literals (which may contain spaces) intact as a single token.
+func tokenizeTriple(line string) []string {
+	var tokens []string
+	var cur strings.Builder
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
+
+// expandTerm strips <...> brackets and expands "prefix:local" names
+// against the declared prefixes; literals and already-expanded IRIs
+// pass through unchanged.
+func expandTerm(term string, prefixes map[string]string) string {
+	term = strings.TrimSpace(term)
+	if strings.HasPrefix(term, "<") && strings.HasSuffix(term, ">") {
+		return strings.TrimSuffix(strings.TrimPrefix(term, "<"), ">")
+	}
+	if strings.HasPrefix(term, "\"") {
+		return term
+	}
+	if idx := strings.Index(term, ":"); idx > 0 {
+		prefix, local := term[:idx], term[idx+1:]
+		if iri, ok := prefixes[prefix]; ok {
+			return iri + local
+		}
+	}
+	return term
+}
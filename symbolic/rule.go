@@ -0,0 +1,35 @@
+package symbolic
+
+import "fmt"
+
+// Atom is one triple pattern inside a rule, e.g. bornIn(x, z). Subject
+// and Object are variable names, not bound values.
+type Atom struct {
+	Predicate string
+	Subject   string
+	Object    string
+}
+
+// Rule is a closed Horn rule Body => Head: every variable in Head also
+// appears in Body, and every variable in Body appears at least twice
+// across Body+Head, mirroring AMIE's closedness requirement so rules
+// are safe to materialize.
+type Rule struct {
+	Body []Atom
+	Head Atom
+
+	Support    int
+	PCABodyHit int // size of the PCA denominator: body matches with a known head-predicate fact for the same subject
+	Confidence float64
+}
+
+func (r Rule) String() string {
+	body := ""
+	for i, a := range r.Body {
+		if i > 0 {
+			body += " ^ "
+		}
+		body += fmt.Sprintf("%s(%s,%s)", a.Predicate, a.Subject, a.Object)
+	}
+	return fmt.Sprintf("%s => %s(%s,%s) [support=%d, conf=%.3f]", body, r.Head.Predicate, r.Head.Subject, r.Head.Object, r.Support, r.Confidence)
+}
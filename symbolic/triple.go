@@ -0,0 +1,115 @@
+// Package symbolic implements a pure-Go replacement for the
+// full_data_wrapper.py bridge: an in-memory triple store plus an
+// AMIE-style Horn rule miner that mines and applies rules without
+// shelling out to Python.
+package symbolic
+
+// Triple is a single RDF statement.
+type Triple struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	ObjectType string // "uri" or "literal"
+}
+
+// Graph is an in-memory triple store indexed three ways so the rule
+// miner can join on whichever position a rule atom anchors on: by
+// (subject, predicate, object), by (predicate, object, subject) and by
+// (predicate, subject, object).
+type Graph struct {
+	Triples []Triple
+
+	// Namespaces holds any @prefix declarations ParseFile saw while
+	// loading this graph (prefix -> IRI), so serializers can compact
+	// terms back into prefixed names.
+	Namespaces map[string]string
+
+	spo map[string]map[string]map[string]bool
+	pos map[string]map[string]map[string]bool
+	pso map[string]map[string]map[string]bool
+}
+
+// NewGraph returns an empty, ready-to-use Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		Namespaces: map[string]string{},
+		spo:        map[string]map[string]map[string]bool{},
+		pos:        map[string]map[string]map[string]bool{},
+		pso:        map[string]map[string]map[string]bool{},
+	}
+}
+
+// Add inserts t into the graph and its three indices. Duplicate
+// triples are silently absorbed.
+func (g *Graph) Add(t Triple) {
+	if g.spo == nil {
+		*g = *NewGraph()
+	}
+	if _, ok := g.spo[t.Subject]; !ok {
+		g.spo[t.Subject] = map[string]map[string]bool{}
+	}
+	if _, ok := g.spo[t.Subject][t.Predicate]; !ok {
+		g.spo[t.Subject][t.Predicate] = map[string]bool{}
+	}
+	if g.spo[t.Subject][t.Predicate][t.Object] {
+		return // already present, skip duplicate bookkeeping
+	}
+	g.spo[t.Subject][t.Predicate][t.Object] = true
+
+	if _, ok := g.pos[t.Predicate]; !ok {
+		g.pos[t.Predicate] = map[string]map[string]bool{}
+	}
+	if _, ok := g.pos[t.Predicate][t.Object]; !ok {
+		g.pos[t.Predicate][t.Object] = map[string]bool{}
+	}
+	g.pos[t.Predicate][t.Object][t.Subject] = true
+
+	if _, ok := g.pso[t.Predicate]; !ok {
+		g.pso[t.Predicate] = map[string]map[string]bool{}
+	}
+	if _, ok := g.pso[t.Predicate][t.Subject]; !ok {
+		g.pso[t.Predicate][t.Subject] = map[string]bool{}
+	}
+	g.pso[t.Predicate][t.Subject][t.Object] = true
+
+	g.Triples = append(g.Triples, t)
+}
+
+// Has reports whether (s, p, o) is in the graph.
+func (g *Graph) Has(s, p, o string) bool {
+	return g.spo[s] != nil && g.spo[s][p] != nil && g.spo[s][p][o]
+}
+
+// ObjectsOf returns every object known for (s, p).
+func (g *Graph) ObjectsOf(s, p string) []string {
+	return keysOf(g.spo[s][p])
+}
+
+// SubjectsOf returns every subject known for (p, o).
+func (g *Graph) SubjectsOf(p, o string) []string {
+	return keysOf(g.pos[p][o])
+}
+
+// ObjectsForPredSubject returns every object known for (p, s), i.e.
+// the same data as ObjectsOf but reached through the (p,s,o) index.
+func (g *Graph) ObjectsForPredSubject(p, s string) []string {
+	return keysOf(g.pso[p][s])
+}
+
+// Subjects returns the distinct subjects that appear under predicate p.
+func (g *Graph) Subjects(p string) []string {
+	return keysOf(g.pso[p])
+}
+
+// Predicates returns every distinct predicate in the graph.
+func (g *Graph) Predicates() []string {
+	return keysOf(g.pos)
+}
+
+func keysOf[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,45 @@
+// Package tracing wires pipeline stages into OpenTelemetry so a full
+// trace of a multi-KG run can be exported to Jaeger/Tempo. Pipeline
+// code only ever calls Tracer(), which defaults to otel's no-op
+// tracer; InitJaeger installs a real exporting TracerProvider for
+// processes (the server subcommand) that want traces shipped
+// somewhere.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/SDM-TIB/DynamicPipeline-KGC/pipeline"
+
+// Tracer returns the tracer every pipeline stage starts its span from.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// InitJaeger points the global TracerProvider at a Jaeger collector's
+// HTTP endpoint (e.g. "http://localhost:14268/api/traces") and returns
+// a shutdown func that flushes and closes the exporter on process exit.
+func InitJaeger(serviceName, endpoint string) (shutdown func(context.Context) error, err error) {
+	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(endpoint)))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+// Violation describes one constraint a focus node failed.
+type Violation struct {
+	FocusNode   string `json:"focus_node"`
+	ResultPath  string `json:"result_path,omitempty"`
+	SourceShape string `json:"source_shape"`
+	Constraint  string `json:"constraint"`
+	Value       string `json:"value,omitempty"`
+	Message     string `json:"message"`
+}
+
+// ValidationReport is the outcome of validating a graph against a set
+// of shapes.
+type ValidationReport struct {
+	Conforms bool        `json:"conforms"`
+	Results  []Violation `json:"results"`
+}
+
+// Validate checks every focus node selected by shapes (via
+// sh:targetClass/sh:targetNode) against its property constraints,
+// walking g's triple index directly rather than running SPARQL.
+func Validate(g *symbolic.Graph, shapes []NodeShape) ValidationReport {
+	byID := map[string]NodeShape{}
+	for _, s := range shapes {
+		byID[s.ID] = s
+	}
+
+	report := ValidationReport{Conforms: true}
+	for _, shape := range shapes {
+		for _, focus := range focusNodes(g, shape) {
+			for _, ps := range shape.Properties {
+				violations := checkProperty(g, byID, shape.ID, focus, ps)
+				report.Results = append(report.Results, violations...)
+			}
+		}
+	}
+	if len(report.Results) > 0 {
+		report.Conforms = false
+	}
+	return report
+}
+
+func focusNodes(g *symbolic.Graph, shape NodeShape) []string {
+	seen := map[string]bool{}
+	var nodes []string
+	for _, n := range shape.TargetNode {
+		if !seen[n] {
+			seen[n] = true
+			nodes = append(nodes, n)
+		}
+	}
+	for _, class := range shape.TargetClass {
+		for _, n := range g.SubjectsOf(rdfType, class) {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
+
+func checkProperty(g *symbolic.Graph, byID map[string]NodeShape, shapeID, focus string, ps PropertyShape) []Violation {
+	if ps.Path == "" {
+		return nil
+	}
+	values := g.ObjectsOf(focus, ps.Path)
+	var violations []Violation
+
+	if ps.MinCount != nil && len(values) < *ps.MinCount {
+		violations = append(violations, violation(focus, ps.Path, shapeID, "sh:minCount",
+			fmt.Sprintf("expected at least %d value(s), got %d", *ps.MinCount, len(values)), ""))
+	}
+	if ps.MaxCount != nil && len(values) > *ps.MaxCount {
+		violations = append(violations, violation(focus, ps.Path, shapeID, "sh:maxCount",
+			fmt.Sprintf("expected at most %d value(s), got %d", *ps.MaxCount, len(values)), ""))
+	}
+
+	for _, v := range values {
+		if ps.Datatype != "" && !hasDatatype(v, ps.Datatype) {
+			violations = append(violations, violation(focus, ps.Path, shapeID, "sh:datatype",
+				fmt.Sprintf("value is not of datatype %s", ps.Datatype), v))
+		}
+		if ps.Class != "" && !g.Has(v, rdfType, ps.Class) {
+			violations = append(violations, violation(focus, ps.Path, shapeID, "sh:class",
+				fmt.Sprintf("value is not an instance of %s", ps.Class), v))
+		}
+		if len(ps.In) > 0 && !contains(ps.In, stripLiteral(v)) {
+			violations = append(violations, violation(focus, ps.Path, shapeID, "sh:in",
+				"value is not in the allowed value list", v))
+		}
+		if ps.Pattern != "" {
+			if ok, err := regexp.MatchString(ps.Pattern, stripLiteral(v)); err != nil || !ok {
+				violations = append(violations, violation(focus, ps.Path, shapeID, "sh:pattern",
+					fmt.Sprintf("value does not match pattern %q", ps.Pattern), v))
+			}
+		}
+		if ps.Node != "" {
+			if nested, ok := byID[ps.Node]; ok {
+				for _, nestedPS := range nested.Properties {
+					violations = append(violations, checkProperty(g, byID, nested.ID, v, nestedPS)...)
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+func violation(focus, path, shapeID, constraint, message, value string) Violation {
+	return Violation{
+		FocusNode:   focus,
+		ResultPath:  path,
+		SourceShape: shapeID,
+		Constraint:  constraint,
+		Value:       value,
+		Message:     message,
+	}
+}
+
+func hasDatatype(value, datatype string) bool {
+	return strings.HasSuffix(value, "^^<"+datatype+">") || strings.HasSuffix(value, "^^"+datatype)
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+func TestValidateReportsMinCountAndInViolations(t *testing.T) {
+	g := symbolic.NewGraph()
+	g.Add(symbolic.Triple{Subject: "ex:alice", Predicate: rdfType, Object: "ex:Person"})
+	g.Add(symbolic.Triple{Subject: "ex:alice", Predicate: "ex:status", Object: "\"bogus\"", ObjectType: "literal"})
+
+	minCount := 1
+	shapes := []NodeShape{{
+		ID:          "ex:PersonShape",
+		TargetClass: []string{"ex:Person"},
+		Properties: []PropertyShape{
+			{Path: "ex:name", MinCount: &minCount},
+			{Path: "ex:status", In: []string{"active", "inactive"}},
+		},
+	}}
+
+	report := Validate(g, shapes)
+	if report.Conforms {
+		t.Fatalf("expected a non-conforming report")
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("got %d violations, want 2: %+v", len(report.Results), report.Results)
+	}
+}
+
+func TestValidateConformsWithNoShapes(t *testing.T) {
+	g := symbolic.NewGraph()
+	report := Validate(g, nil)
+	if !report.Conforms {
+		t.Errorf("expected Conforms=true when there are no shapes to check")
+	}
+}
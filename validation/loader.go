@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+const (
+	rdfType  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirst = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRest  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNil   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+
+	shNodeShape   = "http://www.w3.org/ns/shacl#NodeShape"
+	shTargetClass = "http://www.w3.org/ns/shacl#targetClass"
+	shTargetNode  = "http://www.w3.org/ns/shacl#targetNode"
+	shProperty    = "http://www.w3.org/ns/shacl#property"
+	shPath        = "http://www.w3.org/ns/shacl#path"
+	shMinCount    = "http://www.w3.org/ns/shacl#minCount"
+	shMaxCount    = "http://www.w3.org/ns/shacl#maxCount"
+	shDatatype    = "http://www.w3.org/ns/shacl#datatype"
+	shClass       = "http://www.w3.org/ns/shacl#class"
+	shIn          = "http://www.w3.org/ns/shacl#in"
+	shPattern     = "http://www.w3.org/ns/shacl#pattern"
+	shNode        = "http://www.w3.org/ns/shacl#node"
+)
+
+// LoadShapes reads every .ttl/.nt/.shacl file in folder and extracts
+// the SHACL node shapes it declares. Unlike symbolic.ParseFile (which
+// only understands flat "s p o ." statements), it parses the
+// blank-node property lists and ()-collections real SHACL shapes are
+// almost always written with, e.g. "sh:property [ sh:path ex:name ;
+// sh:minCount 1 ]".
+func LoadShapes(folder string) ([]NodeShape, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("read constraints folder %s: %w", folder, err)
+	}
+
+	g := symbolic.NewGraph()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".ttl" && ext != ".nt" && ext != ".shacl" {
+			continue
+		}
+		if err := parseShapesTurtle(filepath.Join(folder, entry.Name()), g); err != nil {
+			return nil, fmt.Errorf("parse shapes file %s: %w", entry.Name(), err)
+		}
+	}
+
+	return extractShapes(g), nil
+}
+
+// extractShapes walks g looking for sh:NodeShape subjects (including
+// ones only implied by having sh:targetClass/sh:targetNode/sh:property)
+// and builds the NodeShape set from their constraints.
+func extractShapes(g *symbolic.Graph) []NodeShape {
+	ids := map[string]bool{}
+	for _, s := range g.SubjectsOf(rdfType, shNodeShape) {
+		ids[s] = true
+	}
+	for _, p := range []string{shTargetClass, shTargetNode, shProperty} {
+		for _, s := range g.Subjects(p) {
+			ids[s] = true
+		}
+	}
+
+	var shapes []NodeShape
+	for id := range ids {
+		shapes = append(shapes, buildShape(g, id))
+	}
+	return shapes
+}
+
+func buildShape(g *symbolic.Graph, id string) NodeShape {
+	shape := NodeShape{
+		ID:          id,
+		TargetClass: g.ObjectsOf(id, shTargetClass),
+		TargetNode:  g.ObjectsOf(id, shTargetNode),
+	}
+	for _, psID := range g.ObjectsOf(id, shProperty) {
+		shape.Properties = append(shape.Properties, buildPropertyShape(g, psID))
+	}
+	return shape
+}
+
+func buildPropertyShape(g *symbolic.Graph, psID string) PropertyShape {
+	ps := PropertyShape{}
+	if paths := g.ObjectsOf(psID, shPath); len(paths) > 0 {
+		ps.Path = paths[0]
+	}
+	if counts := g.ObjectsOf(psID, shMinCount); len(counts) > 0 {
+		if n, err := strconv.Atoi(stripLiteral(counts[0])); err == nil {
+			ps.MinCount = &n
+		}
+	}
+	if counts := g.ObjectsOf(psID, shMaxCount); len(counts) > 0 {
+		if n, err := strconv.Atoi(stripLiteral(counts[0])); err == nil {
+			ps.MaxCount = &n
+		}
+	}
+	if dts := g.ObjectsOf(psID, shDatatype); len(dts) > 0 {
+		ps.Datatype = dts[0]
+	}
+	if classes := g.ObjectsOf(psID, shClass); len(classes) > 0 {
+		ps.Class = classes[0]
+	}
+	if nodes := g.ObjectsOf(psID, shNode); len(nodes) > 0 {
+		ps.Node = nodes[0]
+	}
+	if patterns := g.ObjectsOf(psID, shPattern); len(patterns) > 0 {
+		ps.Pattern = stripLiteral(patterns[0])
+	}
+	if lists := g.ObjectsOf(psID, shIn); len(lists) > 0 {
+		ps.In = readList(g, lists[0])
+	}
+	return ps
+}
+
+// readList walks an rdf:first/rdf:rest list starting at head and
+// returns its elements in order.
+func readList(g *symbolic.Graph, head string) []string {
+	var out []string
+	node := head
+	for node != "" && node != rdfNil {
+		firsts := g.ObjectsOf(node, rdfFirst)
+		if len(firsts) == 0 {
+			break
+		}
+		out = append(out, stripLiteral(firsts[0]))
+
+		rests := g.ObjectsOf(node, rdfRest)
+		if len(rests) == 0 {
+			break
+		}
+		node = rests[0]
+	}
+	return out
+}
+
+// stripLiteral strips the surrounding quotes (and any ^^datatype or
+// @lang suffix) from a literal term, leaving plain IRIs untouched.
+func stripLiteral(term string) string {
+	if !strings.HasPrefix(term, "\"") {
+		return term
+	}
+	end := strings.LastIndex(term, "\"")
+	if end <= 0 {
+		return term
+	}
+	return term[1:end]
+}
@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+// TestLoadShapesBlankNodeProperty exercises the idiomatic SHACL form
+// LoadShapes previously failed to parse: a sh:property blank node and
+// an sh:in collection, both written with ;/() abbreviations instead of
+// flat "s p o ." statements.
+func TestLoadShapesBlankNodeProperty(t *testing.T) {
+	dir := t.TempDir()
+	shapes := `@prefix sh: <http://www.w3.org/ns/shacl#> .
+@prefix ex: <http://example.org/> .
+
+ex:PersonShape a sh:NodeShape ;
+    sh:targetClass ex:Person ;
+    sh:property [
+        sh:path ex:name ;
+        sh:minCount 1 ;
+    ] ;
+    sh:property [
+        sh:path ex:status ;
+        sh:in ( "active" "inactive" ) ;
+    ] .
+`
+	if err := os.WriteFile(filepath.Join(dir, "person.ttl"), []byte(shapes), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	got, err := LoadShapes(dir)
+	if err != nil {
+		t.Fatalf("LoadShapes: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d shapes, want 1", len(got))
+	}
+
+	shape := got[0]
+	if len(shape.Properties) != 2 {
+		t.Fatalf("got %d property shapes, want 2", len(shape.Properties))
+	}
+
+	var nameProp, statusProp *PropertyShape
+	for i := range shape.Properties {
+		switch shape.Properties[i].Path {
+		case "http://example.org/name":
+			nameProp = &shape.Properties[i]
+		case "http://example.org/status":
+			statusProp = &shape.Properties[i]
+		}
+	}
+	if nameProp == nil || nameProp.MinCount == nil || *nameProp.MinCount != 1 {
+		t.Errorf("ex:name property shape missing or wrong minCount: %+v", nameProp)
+	}
+	if statusProp == nil || len(statusProp.In) != 2 || statusProp.In[0] != "active" || statusProp.In[1] != "inactive" {
+		t.Errorf("ex:status property shape missing or wrong sh:in list: %+v", statusProp)
+	}
+}
+
+func TestExtractShapesEmptyGraph(t *testing.T) {
+	g := symbolic.NewGraph()
+	if got := extractShapes(g); len(got) != 0 {
+		t.Errorf("got %d shapes from an empty graph, want 0", len(got))
+	}
+}
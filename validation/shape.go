@@ -0,0 +1,27 @@
+// Package validation implements a Go-native SHACL validator over the
+// same symbolic.Graph triple index the rule engine mines, so predicted
+// triples can be checked against constraints without a round trip
+// through an external SHACL engine.
+package validation
+
+// PropertyShape is one sh:property constraint on a NodeShape.
+type PropertyShape struct {
+	Path     string
+	MinCount *int
+	MaxCount *int
+	Datatype string
+	Class    string
+	In       []string
+	Pattern  string
+	Node     string // ID of a nested NodeShape, resolved against the shape set at validation time
+}
+
+// NodeShape is a sh:NodeShape: a set of focus-node selectors
+// (TargetClass, TargetNode) plus the property constraints that apply
+// to every node they select.
+type NodeShape struct {
+	ID          string
+	TargetClass []string
+	TargetNode  []string
+	Properties  []PropertyShape
+}
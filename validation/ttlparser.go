@@ -0,0 +1,417 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/SDM-TIB/DynamicPipeline-KGC/symbolic"
+)
+
+// This file implements the richer Turtle subset real SHACL shape
+// files are written in: blank-node property lists ("sh:property [
+// sh:path ex:name ; sh:minCount 1 ]"), ()-collections (for sh:in
+// lists), and ";"/","-abbreviated predicate/object lists.
+// symbolic.ParseFile deliberately only understands flat "s p o ."
+// statements, so shapes need their own parser; it feeds the same
+// symbolic.Triple/Graph types extractShapes already walks.
+
+// ttlToken is one lexical token from a shapes file.
+type ttlToken struct {
+	kind   string // "iri", "word", "blank", "literal", "punct", "at", "eof"
+	val    string
+	dtKind string // for "literal": kind of the ^^datatype token ("iri" or "word"), empty if none
+	dtRaw  string // for "literal": raw value of the ^^datatype token
+	lang   string // for "literal": @lang tag, if any
+}
+
+type ttlLexer struct {
+	runes []rune
+	pos   int
+}
+
+func newTTLLexer(s string) *ttlLexer {
+	return &ttlLexer{runes: []rune(s)}
+}
+
+func (l *ttlLexer) skipWS() {
+	for l.pos < len(l.runes) {
+		r := l.runes[l.pos]
+		if r == '#' {
+			for l.pos < len(l.runes) && l.runes[l.pos] != '\n' {
+				l.pos++
+			}
+			continue
+		}
+		if unicode.IsSpace(r) {
+			l.pos++
+			continue
+		}
+		break
+	}
+}
+
+func isTTLNameChar(r rune) bool {
+	switch r {
+	case '.', ';', ',', '[', ']', '(', ')', '<', '>', '"', '#', '@':
+		return false
+	}
+	return !unicode.IsSpace(r)
+}
+
+func (l *ttlLexer) next() (ttlToken, error) {
+	l.skipWS()
+	if l.pos >= len(l.runes) {
+		return ttlToken{kind: "eof"}, nil
+	}
+	r := l.runes[l.pos]
+	switch {
+	case r == '<':
+		start := l.pos + 1
+		end := start
+		for end < len(l.runes) && l.runes[end] != '>' {
+			end++
+		}
+		if end >= len(l.runes) {
+			return ttlToken{}, fmt.Errorf("unterminated IRI")
+		}
+		val := string(l.runes[start:end])
+		l.pos = end + 1
+		return ttlToken{kind: "iri", val: val}, nil
+	case r == '"':
+		return l.lexLiteral()
+	case r == '_' && l.pos+1 < len(l.runes) && l.runes[l.pos+1] == ':':
+		start := l.pos
+		l.pos += 2
+		for l.pos < len(l.runes) && isTTLNameChar(l.runes[l.pos]) {
+			l.pos++
+		}
+		return ttlToken{kind: "blank", val: string(l.runes[start:l.pos])}, nil
+	case r == '.' || r == ';' || r == ',' || r == '[' || r == ']' || r == '(' || r == ')':
+		l.pos++
+		return ttlToken{kind: "punct", val: string(r)}, nil
+	case r == '@':
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.runes) && isTTLNameChar(l.runes[l.pos]) {
+			l.pos++
+		}
+		return ttlToken{kind: "at", val: string(l.runes[start:l.pos])}, nil
+	default:
+		start := l.pos
+		for l.pos < len(l.runes) && isTTLNameChar(l.runes[l.pos]) {
+			l.pos++
+		}
+		if l.pos == start {
+			return ttlToken{}, fmt.Errorf("unexpected character %q", string(r))
+		}
+		return ttlToken{kind: "word", val: string(l.runes[start:l.pos])}, nil
+	}
+}
+
+// lexLiteral reads a "..." string (with \" escapes) and any trailing
+// ^^datatype or @lang tag.
+func (l *ttlLexer) lexLiteral() (ttlToken, error) {
+	l.pos++ // opening quote
+	var sb strings.Builder
+	for l.pos < len(l.runes) {
+		r := l.runes[l.pos]
+		if r == '\\' && l.pos+1 < len(l.runes) {
+			sb.WriteRune(l.runes[l.pos+1])
+			l.pos += 2
+			continue
+		}
+		if r == '"' {
+			l.pos++
+			tok := ttlToken{kind: "literal", val: sb.String()}
+			if l.pos+1 < len(l.runes) && l.runes[l.pos] == '^' && l.runes[l.pos+1] == '^' {
+				l.pos += 2
+				dt, err := l.next()
+				if err != nil {
+					return ttlToken{}, err
+				}
+				tok.dtKind, tok.dtRaw = dt.kind, dt.val
+			} else if l.pos < len(l.runes) && l.runes[l.pos] == '@' {
+				l.pos++
+				start := l.pos
+				for l.pos < len(l.runes) && (unicode.IsLetter(l.runes[l.pos]) || l.runes[l.pos] == '-') {
+					l.pos++
+				}
+				tok.lang = string(l.runes[start:l.pos])
+			}
+			return tok, nil
+		}
+		sb.WriteRune(r)
+		l.pos++
+	}
+	return ttlToken{}, fmt.Errorf("unterminated string literal")
+}
+
+// ttlParser turns a shapes file's token stream into triples added
+// directly to a symbolic.Graph, synthesizing blank node IDs for
+// "[ ... ]" property lists and "( ... )" collections.
+type ttlParser struct {
+	lex      *ttlLexer
+	prefixes map[string]string
+	g        *symbolic.Graph
+	blankSeq int
+	cur      ttlToken
+}
+
+func newTTLParser(content string, g *symbolic.Graph) *ttlParser {
+	return &ttlParser{lex: newTTLLexer(content), prefixes: map[string]string{}, g: g}
+}
+
+func (p *ttlParser) advance() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.cur = t
+	return nil
+}
+
+func (p *ttlParser) parse() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	for p.cur.kind != "eof" {
+		if p.cur.kind == "at" {
+			if err := p.parseDirective(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.parseTriples(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDirective consumes "@prefix p: <iri> ." (prefixes carry over
+// for the rest of the file) or "@base <iri> ." (accepted but unused,
+// since every shape file in this pipeline names absolute IRIs).
+func (p *ttlParser) parseDirective() error {
+	kw := strings.ToLower(p.cur.val)
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if kw == "@prefix" {
+		if p.cur.kind != "word" {
+			return fmt.Errorf("expected prefix name after @prefix, got %q", p.cur.val)
+		}
+		prefix := strings.TrimSuffix(p.cur.val, ":")
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if p.cur.kind != "iri" {
+			return fmt.Errorf("expected IRI after @prefix %s:", prefix)
+		}
+		p.prefixes[prefix] = p.cur.val
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.cur.kind == "punct" && p.cur.val == "." {
+		return p.advance()
+	}
+	return nil
+}
+
+func (p *ttlParser) parseTriples() error {
+	subj, err := p.parseTerm()
+	if err != nil {
+		return err
+	}
+	if err := p.parsePredicateObjectList(subj); err != nil {
+		return err
+	}
+	if p.cur.kind == "punct" && p.cur.val == "." {
+		return p.advance()
+	}
+	return nil
+}
+
+// parsePredicateObjectList parses "p1 o1, o2 ; p2 o3 ..." for subj,
+// stopping at "." or the closing "]" of an enclosing property list.
+func (p *ttlParser) parsePredicateObjectList(subj string) error {
+	for {
+		if p.cur.kind == "eof" || (p.cur.kind == "punct" && (p.cur.val == "." || p.cur.val == "]")) {
+			return nil
+		}
+		pred, err := p.parseTerm()
+		if err != nil {
+			return err
+		}
+		if err := p.parseObjectList(subj, pred); err != nil {
+			return err
+		}
+		if p.cur.kind == "punct" && p.cur.val == ";" {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			continue
+		}
+		return nil
+	}
+}
+
+func (p *ttlParser) parseObjectList(subj, pred string) error {
+	for {
+		obj, err := p.parseTerm()
+		if err != nil {
+			return err
+		}
+		p.g.Add(symbolic.Triple{Subject: subj, Predicate: pred, Object: obj, ObjectType: ttlObjectType(obj)})
+		if p.cur.kind == "punct" && p.cur.val == "," {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			continue
+		}
+		return nil
+	}
+}
+
+// parseTerm parses a single subject/predicate/object position: an
+// IRI, prefixed name, "a", blank node label, literal, blank-node
+// property list, or collection.
+func (p *ttlParser) parseTerm() (string, error) {
+	switch p.cur.kind {
+	case "iri":
+		v := p.cur.val
+		return v, p.advance()
+	case "blank":
+		v := p.cur.val
+		return v, p.advance()
+	case "word":
+		v := p.expandWord(p.cur.val)
+		return v, p.advance()
+	case "literal":
+		v := p.literalTerm(p.cur)
+		return v, p.advance()
+	case "punct":
+		switch p.cur.val {
+		case "[":
+			return p.parseBlankNodePropertyList()
+		case "(":
+			return p.parseCollection()
+		}
+	}
+	return "", fmt.Errorf("unexpected token %q", p.cur.val)
+}
+
+func (p *ttlParser) parseBlankNodePropertyList() (string, error) {
+	if err := p.advance(); err != nil { // consume '['
+		return "", err
+	}
+	bn := p.newBlankNode()
+	if p.cur.kind == "punct" && p.cur.val == "]" {
+		return bn, p.advance()
+	}
+	if err := p.parsePredicateObjectList(bn); err != nil {
+		return "", err
+	}
+	if p.cur.kind != "punct" || p.cur.val != "]" {
+		return "", fmt.Errorf("expected ']', got %q", p.cur.val)
+	}
+	return bn, p.advance()
+}
+
+// parseCollection parses a "( a b c )" list into an rdf:first/rdf:rest
+// chain of fresh blank nodes and returns its head (or rdf:nil if
+// empty), the shape this pipeline uses for sh:in value lists.
+func (p *ttlParser) parseCollection() (string, error) {
+	if err := p.advance(); err != nil { // consume '('
+		return "", err
+	}
+	var items []string
+	for !(p.cur.kind == "punct" && p.cur.val == ")") {
+		item, err := p.parseTerm()
+		if err != nil {
+			return "", err
+		}
+		items = append(items, item)
+	}
+	if err := p.advance(); err != nil { // consume ')'
+		return "", err
+	}
+	if len(items) == 0 {
+		return rdfNil, nil
+	}
+
+	head := p.newBlankNode()
+	node := head
+	for i, item := range items {
+		p.g.Add(symbolic.Triple{Subject: node, Predicate: rdfFirst, Object: item, ObjectType: ttlObjectType(item)})
+		if i == len(items)-1 {
+			p.g.Add(symbolic.Triple{Subject: node, Predicate: rdfRest, Object: rdfNil, ObjectType: "uri"})
+			break
+		}
+		next := p.newBlankNode()
+		p.g.Add(symbolic.Triple{Subject: node, Predicate: rdfRest, Object: next, ObjectType: "uri"})
+		node = next
+	}
+	return head, nil
+}
+
+func (p *ttlParser) newBlankNode() string {
+	p.blankSeq++
+	return fmt.Sprintf("_:shb%d", p.blankSeq)
+}
+
+// expandWord resolves "a" and "prefix:local" words against the
+// declared prefixes; bare numbers/booleans pass through unchanged, the
+// same literal form stripLiteral already expects from symbolic.ParseFile.
+func (p *ttlParser) expandWord(word string) string {
+	if word == "a" {
+		return rdfType
+	}
+	if idx := strings.Index(word, ":"); idx >= 0 {
+		prefix, local := word[:idx], word[idx+1:]
+		if iri, ok := p.prefixes[prefix]; ok {
+			return iri + local
+		}
+	}
+	return word
+}
+
+// literalTerm rebuilds a quoted literal's string form, keeping the
+// surrounding quotes (and any ^^datatype/@lang suffix) the way
+// symbolic.ParseFile's literals already carry them, so stripLiteral
+// and hasDatatype keep working unchanged.
+func (p *ttlParser) literalTerm(tok ttlToken) string {
+	term := "\"" + tok.val + "\""
+	switch {
+	case tok.dtKind == "iri":
+		term += "^^" + tok.dtRaw
+	case tok.dtKind == "word":
+		term += "^^" + p.expandWord(tok.dtRaw)
+	case tok.lang != "":
+		term += "@" + tok.lang
+	}
+	return term
+}
+
+func ttlObjectType(term string) string {
+	if strings.HasPrefix(term, "\"") {
+		return "literal"
+	}
+	return "uri"
+}
+
+// parseShapesTurtle parses path's blank-node/collection-aware Turtle
+// into g, adding every triple it finds.
+func parseShapesTurtle(path string, g *symbolic.Graph) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	p := newTTLParser(string(data), g)
+	if err := p.parse(); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}